@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -70,6 +71,11 @@ func init() {
 
 	format.AddFlag(dumpMeta)
 	dumpMeta.Flags().BoolVar(&p.ParseTests, "tests", false, "Parse tests as well")
+	dumpMeta.Flags().BoolVar(&p.Compact, "compact", false, "Output compact JSON without indentation")
+	dumpMeta.Flags().BoolVar(&p.CamelCase, "camel-case", false, "Use camelCase field names in JSON output")
+	dumpMeta.Flags().BoolVar(&p.CheckSecrets, "check-secrets", false, "Report secrets used by the app that are not defined")
+	dumpMeta.Flags().BoolVar(&p.StrictSecrets, "strict-secrets", false, "Fail if any secrets used by the app are not defined")
+	dumpMeta.Flags().StringVar(&p.Service, "service", "", "Only output the metadata for the given service")
 	rootCmd.AddCommand(debugCmd)
 	debugCmd.AddCommand(buildCmd)
 	debugCmd.AddCommand(dumpMeta)
@@ -100,12 +106,23 @@ type dumpMetaParams struct {
 	ParseTests bool
 	Format     daemonpb.DumpMetaRequest_Format
 	Environ    []string
+	Service    string
+	Compact    bool
+	CamelCase  bool
+
+	CheckSecrets  bool
+	StrictSecrets bool
 }
 
 func dumpMeta(p dumpMetaParams) {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer cancel()
 
+	var service *string
+	if p.Service != "" {
+		service = &p.Service
+	}
+
 	daemon := setupDaemon(ctx)
 	resp, err := daemon.DumpMeta(ctx, &daemonpb.DumpMetaRequest{
 		AppRoot:    p.AppRoot,
@@ -113,9 +130,18 @@ func dumpMeta(p dumpMetaParams) {
 		ParseTests: p.ParseTests,
 		Environ:    p.Environ,
 		Format:     p.Format,
+		Service:    service,
+		Compact:    p.Compact,
+		CamelCase:  p.CamelCase,
+
+		CheckSecrets:  p.CheckSecrets,
+		StrictSecrets: p.StrictSecrets,
 	})
 	if err != nil {
 		fatal(err)
 	}
+	if len(resp.MissingSecrets) > 0 {
+		fmt.Fprintf(os.Stderr, "warning: missing secrets: %s\n", strings.Join(resp.MissingSecrets, ", "))
+	}
 	_, _ = os.Stdout.Write(resp.Meta)
 }
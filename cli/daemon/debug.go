@@ -2,20 +2,27 @@ package daemon
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"runtime"
+	"slices"
+	"strings"
 
 	"github.com/golang/protobuf/jsonpb"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
+	"encr.dev/cli/daemon/run"
+	"encr.dev/cli/daemon/secret"
 	"encr.dev/internal/version"
 	"encr.dev/pkg/builder"
 	"encr.dev/pkg/builder/builderimpl"
+	"encr.dev/pkg/errlist"
 	"encr.dev/pkg/fns"
 	"encr.dev/pkg/vcs"
 	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
 )
 
 func (s *Server) DumpMeta(ctx context.Context, req *daemonpb.DumpMetaRequest) (*daemonpb.DumpMetaResponse, error) {
@@ -29,10 +36,17 @@ func (s *Server) DumpMeta(ctx context.Context, req *daemonpb.DumpMetaRequest) (*
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	// TODO: We should check that all secret keys are defined as well.
+	// Start loading the secrets in the background, if we need them.
+	checkSecrets := req.CheckSecrets || req.StrictSecrets
+	var secrets *secret.LoadResult
+	if checkSecrets {
+		secrets = s.sm.Load(app)
+	}
 
 	vcsRevision := vcs.GetRevision(app.Root())
 	buildInfo := builder.BuildInfo{
+		// There are no test build tags to exclude: test files are
+		// excluded by name by the parser unless ParseTests is set.
 		BuildTags:          builder.LocalBuildTags,
 		CgoEnabled:         true,
 		StaticLink:         false,
@@ -67,26 +81,164 @@ func (s *Server) DumpMeta(ctx context.Context, req *daemonpb.DumpMetaRequest) (*
 		Prepare:     prepareResult,
 	})
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return nil, parseErrorStatus(err)
+	}
+
+	var missingSecrets []string
+	if checkSecrets {
+		data, err := secrets.Get(ctx, expSet)
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "failed to load secrets: %v", err)
+		}
+		missingSecrets, err = checkMissingSecrets(parse.Meta, data.Values, req.StrictSecrets)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	md := parse.Meta
+	if svcName := req.GetService(); svcName != "" {
+		var ok bool
+		md, ok = filterMetaByService(md, svcName)
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "service %q not found", svcName)
+		}
 	}
 
-	var out []byte
+	out, err := marshalMeta(md, req)
+	if err != nil {
+		return nil, err
+	}
+	return &daemonpb.DumpMetaResponse{Meta: out, MissingSecrets: missingSecrets}, nil
+}
+
+// checkMissingSecrets returns the secrets used by md that are not defined.
+// If strict is true it instead reports an error if there are any.
+func checkMissingSecrets(md *meta.Data, definedSecrets map[string]string, strict bool) ([]string, error) {
+	missing := run.MissingSecrets(md, definedSecrets)
+	if strict && len(missing) > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "missing secrets: %s", strings.Join(missing, ", "))
+	}
+	return missing, nil
+}
+
+// parseErrorStatus converts a parse error into a gRPC status error,
+// attaching the parse errors as structured details when possible.
+func parseErrorStatus(err error) error {
+	st := status.New(codes.InvalidArgument, err.Error())
+	list := errlist.Convert(err)
+	if list == nil {
+		return st.Err()
+	}
+
+	details := &daemonpb.ParseErrors{}
+	for _, e := range list.List {
+		pe := &daemonpb.ParseError{
+			Title:   e.Params.Title,
+			Summary: e.Params.Summary,
+		}
+		for _, loc := range e.Params.Locations {
+			var file string
+			if loc.File != nil {
+				file = cmp.Or(loc.File.RelPath, loc.File.FullPath)
+			}
+			pe.Locations = append(pe.Locations, &daemonpb.ParseError_Location{
+				File:      file,
+				Line:      int32(loc.Start.Line),
+				Column:    int32(loc.Start.Col),
+				EndLine:   int32(loc.End.Line),
+				EndColumn: int32(loc.End.Col),
+				Text:      loc.Text,
+			})
+		}
+		details.Errors = append(details.Errors, pe)
+	}
+
+	if withDetails, detailsErr := st.WithDetails(details); detailsErr == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// marshalMeta marshals md in the format requested by req.
+func marshalMeta(md *meta.Data, req *daemonpb.DumpMetaRequest) ([]byte, error) {
 	switch req.Format {
 	case daemonpb.DumpMetaRequest_FORMAT_PROTO:
-		out, err = proto.Marshal(parse.Meta)
+		out, err := proto.Marshal(md)
 		if err != nil {
 			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
+		return out, nil
 	case daemonpb.DumpMetaRequest_FORMAT_JSON:
 		var buf bytes.Buffer
-		m := &jsonpb.Marshaler{OrigName: true, EmitDefaults: true, Indent: "  "}
-		if err := m.Marshal(&buf, parse.Meta); err != nil {
+		m := &jsonpb.Marshaler{OrigName: !req.CamelCase, EmitDefaults: true, Indent: "  "}
+		if req.Compact {
+			m.Indent = ""
+		}
+		if err := m.Marshal(&buf, md); err != nil {
 			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
-		out = buf.Bytes()
+		return buf.Bytes(), nil
 	default:
 		return nil, status.Error(codes.InvalidArgument, "invalid format")
 	}
+}
+
+// filterMetaByService returns a copy of md that only includes the given service,
+// its packages, and the resources it uses. It reports false if the service doesn't exist.
+func filterMetaByService(md *meta.Data, svcName string) (*meta.Data, bool) {
+	svc, ok := fns.Find(md.Svcs, func(svc *meta.Service) bool { return svc.Name == svcName })
+	if !ok {
+		return nil, false
+	}
+	md = proto.Clone(md).(*meta.Data)
+	svc = proto.Clone(svc).(*meta.Service)
+	md.Svcs = []*meta.Service{svc}
+
+	svcPkgs := make(map[string]bool)
+	md.Pkgs = slices.DeleteFunc(md.Pkgs, func(pkg *meta.Package) bool {
+		if pkg.ServiceName != svcName {
+			return true
+		}
+		svcPkgs[pkg.RelPath] = true
+		return false
+	})
+
+	md.SqlDatabases = slices.DeleteFunc(md.SqlDatabases, func(db *meta.SQLDatabase) bool {
+		return !slices.Contains(svc.Databases, db.Name)
+	})
+	md.Buckets = slices.DeleteFunc(md.Buckets, func(bkt *meta.Bucket) bool {
+		return !slices.ContainsFunc(svc.Buckets, func(u *meta.BucketUsage) bool { return u.Bucket == bkt.Name })
+	})
+	md.Metrics = slices.DeleteFunc(md.Metrics, func(m *meta.Metric) bool {
+		return !slices.Contains(svc.Metrics, m.Name)
+	})
+
+	md.PubsubTopics = slices.DeleteFunc(md.PubsubTopics, func(topic *meta.PubSubTopic) bool {
+		topic.Publishers = slices.DeleteFunc(topic.Publishers, func(p *meta.PubSubTopic_Publisher) bool {
+			return p.ServiceName != svcName
+		})
+		topic.Subscriptions = slices.DeleteFunc(topic.Subscriptions, func(s *meta.PubSubTopic_Subscription) bool {
+			return s.ServiceName != svcName
+		})
+		return len(topic.Publishers) == 0 && len(topic.Subscriptions) == 0
+	})
+	md.CacheClusters = slices.DeleteFunc(md.CacheClusters, func(cluster *meta.CacheCluster) bool {
+		cluster.Keyspaces = slices.DeleteFunc(cluster.Keyspaces, func(ks *meta.CacheCluster_Keyspace) bool {
+			return ks.Service != svcName
+		})
+		return len(cluster.Keyspaces) == 0
+	})
+
+	md.CronJobs = slices.DeleteFunc(md.CronJobs, func(job *meta.CronJob) bool {
+		return !svcPkgs[job.Endpoint.GetPkg()]
+	})
+	md.Middleware = slices.DeleteFunc(md.Middleware, func(mw *meta.Middleware) bool {
+		return !mw.Global && mw.GetServiceName() != svcName
+	})
+	md.Gateways = slices.DeleteFunc(md.Gateways, func(gw *meta.Gateway) bool {
+		return gw.Explicit != nil && gw.Explicit.ServiceName != svcName
+	})
 
-	return &daemonpb.DumpMetaResponse{Meta: out}, nil
+	return md, true
 }
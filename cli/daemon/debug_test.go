@@ -0,0 +1,146 @@
+package daemon
+
+import (
+	"bytes"
+	"errors"
+	"go/token"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"encr.dev/pkg/errinsrc/srcerrors"
+	"encr.dev/pkg/fns"
+	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestFilterMetaByService(t *testing.T) {
+	c := qt.New(t)
+
+	md := &meta.Data{
+		Pkgs: []*meta.Package{
+			{RelPath: "foo", ServiceName: "foo"},
+			{RelPath: "bar", ServiceName: "bar"},
+		},
+		Svcs: []*meta.Service{
+			{Name: "foo", RelPath: "foo", Databases: []string{"foodb"}},
+			{Name: "bar", RelPath: "bar", Databases: []string{"bardb"}},
+		},
+		SqlDatabases: []*meta.SQLDatabase{{Name: "foodb"}, {Name: "bardb"}},
+		PubsubTopics: []*meta.PubSubTopic{
+			{
+				Name:          "shared",
+				Publishers:    []*meta.PubSubTopic_Publisher{{ServiceName: "bar"}},
+				Subscriptions: []*meta.PubSubTopic_Subscription{{Name: "sub", ServiceName: "foo"}},
+			},
+			{
+				Name:       "bar-only",
+				Publishers: []*meta.PubSubTopic_Publisher{{ServiceName: "bar"}},
+			},
+		},
+		CronJobs: []*meta.CronJob{
+			{Id: "foo-job", Endpoint: &meta.QualifiedName{Pkg: "foo", Name: "Job"}},
+			{Id: "bar-job", Endpoint: &meta.QualifiedName{Pkg: "bar", Name: "Job"}},
+		},
+	}
+
+	got, ok := filterMetaByService(md, "foo")
+	c.Assert(ok, qt.IsTrue)
+
+	c.Assert(fns.Map(got.Svcs, func(s *meta.Service) string { return s.Name }), qt.DeepEquals, []string{"foo"})
+	c.Assert(fns.Map(got.Pkgs, func(p *meta.Package) string { return p.RelPath }), qt.DeepEquals, []string{"foo"})
+	c.Assert(fns.Map(got.SqlDatabases, func(db *meta.SQLDatabase) string { return db.Name }), qt.DeepEquals, []string{"foodb"})
+	c.Assert(fns.Map(got.PubsubTopics, func(t *meta.PubSubTopic) string { return t.Name }), qt.DeepEquals, []string{"shared"})
+	c.Assert(got.PubsubTopics[0].Publishers, qt.HasLen, 0)
+	c.Assert(fns.Map(got.CronJobs, func(j *meta.CronJob) string { return j.Id }), qt.DeepEquals, []string{"foo-job"})
+
+	// The original metadata is left untouched.
+	c.Assert(md.Svcs, qt.HasLen, 2)
+	c.Assert(md.PubsubTopics[0].Publishers, qt.HasLen, 1)
+
+	_, ok = filterMetaByService(md, "unknown")
+	c.Assert(ok, qt.IsFalse)
+}
+
+func TestMarshalMeta_Compact(t *testing.T) {
+	c := qt.New(t)
+
+	md := &meta.Data{
+		Svcs: []*meta.Service{{Name: "foo", RelPath: "foo"}, {Name: "bar", RelPath: "bar"}},
+	}
+	indented, err := marshalMeta(md, &daemonpb.DumpMetaRequest{Format: daemonpb.DumpMetaRequest_FORMAT_JSON})
+	c.Assert(err, qt.IsNil)
+	compact, err := marshalMeta(md, &daemonpb.DumpMetaRequest{Format: daemonpb.DumpMetaRequest_FORMAT_JSON, Compact: true})
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(len(compact) < len(indented), qt.IsTrue, qt.Commentf("compact: %d bytes, indented: %d bytes", len(compact), len(indented)))
+	c.Assert(bytes.Contains(compact, []byte("\n")), qt.IsFalse)
+	// Zero values are kept, so the compact output has the same fields.
+	c.Assert(bytes.Contains(compact, []byte(`"has_config":false`)), qt.IsTrue)
+	c.Assert(bytes.Contains(indented, []byte(`"has_config"`)), qt.IsTrue)
+}
+
+func TestMarshalMeta_CamelCase(t *testing.T) {
+	c := qt.New(t)
+
+	md := &meta.Data{
+		ModulePath: "example.com/app",
+		Language:   meta.Lang_TYPESCRIPT,
+	}
+	snake, err := marshalMeta(md, &daemonpb.DumpMetaRequest{Format: daemonpb.DumpMetaRequest_FORMAT_JSON, Compact: true})
+	c.Assert(err, qt.IsNil)
+	camel, err := marshalMeta(md, &daemonpb.DumpMetaRequest{Format: daemonpb.DumpMetaRequest_FORMAT_JSON, Compact: true, CamelCase: true})
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(string(snake), qt.Contains, `"module_path":"example.com/app"`)
+	c.Assert(string(snake), qt.Not(qt.Contains), `"modulePath"`)
+	c.Assert(string(camel), qt.Contains, `"modulePath":"example.com/app"`)
+	c.Assert(string(camel), qt.Not(qt.Contains), `"module_path"`)
+}
+
+func TestParseErrorStatus(t *testing.T) {
+	c := qt.New(t)
+
+	err := srcerrors.GenericError(token.Position{Filename: "svc/svc.go", Line: 3, Column: 5}, "something went wrong")
+	st := status.Convert(parseErrorStatus(err))
+	c.Assert(st.Code(), qt.Equals, codes.InvalidArgument)
+	c.Assert(st.Message(), qt.Equals, err.Error())
+
+	details := st.Details()
+	c.Assert(details, qt.HasLen, 1)
+	parseErrs, ok := details[0].(*daemonpb.ParseErrors)
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(parseErrs.Errors, qt.HasLen, 1)
+
+	pe := parseErrs.Errors[0]
+	c.Assert(pe.Summary, qt.Equals, "something went wrong")
+	c.Assert(pe.Locations, qt.HasLen, 1)
+	c.Assert(pe.Locations[0].File, qt.Equals, "svc/svc.go")
+	c.Assert(pe.Locations[0].Line, qt.Equals, int32(3))
+	c.Assert(pe.Locations[0].Column, qt.Equals, int32(5))
+
+	// Errors without source information have no details.
+	st = status.Convert(parseErrorStatus(errors.New("plain error")))
+	c.Assert(st.Details(), qt.HasLen, 0)
+}
+
+func TestCheckMissingSecrets(t *testing.T) {
+	c := qt.New(t)
+
+	md := &meta.Data{
+		Pkgs: []*meta.Package{
+			{RelPath: "foo", ServiceName: "foo", Secrets: []string{"Defined", "Undefined"}},
+		},
+	}
+	defined := map[string]string{"Defined": "value"}
+
+	missing, err := checkMissingSecrets(md, defined, false)
+	c.Assert(err, qt.IsNil)
+	c.Assert(missing, qt.DeepEquals, []string{"Undefined"})
+
+	_, err = checkMissingSecrets(md, defined, true)
+	c.Assert(status.Code(err), qt.Equals, codes.FailedPrecondition)
+	c.Assert(err, qt.ErrorMatches, `.*missing secrets: Undefined`)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/cockroachdb/errors"
 
+	"encore.dev/appruntime/exported/config"
 	"encr.dev/cli/daemon/apps"
 	"encr.dev/cli/daemon/namespace"
 	"encr.dev/cli/daemon/run/infra"
@@ -170,25 +171,27 @@ func (mgr *Manager) ExecSpec(ctx context.Context, p ExecSpecParams) (*ExecSpecRe
 
 	authKey := genAuthKey()
 	configGen := &RuntimeConfigGenerator{
-		app:               p.App,
-		infraManager:      rm,
-		md:                parse.Meta,
-		AppID:             option.Some(GenID()),
-		EnvID:             option.Some(GenID()),
-		TraceEndpoint:     option.Some(fmt.Sprintf("http://localhost:%d/trace", mgr.RuntimePort)),
-		AuthKey:           authKey,
-		Gateways:          gateways,
-		DefinedSecrets:    secrets,
-		SvcConfigs:        cfg.Configs,
-		IncludeMeta:       bld.NeedsMeta(),
-		MetaPath:          option.Some(filepath.Join(p.TempDir, "meta.pb")),
-		RuntimeConfigPath: option.Some(filepath.Join(p.TempDir, "runtime_config.pb")),
+		app:                 p.App,
+		infraManager:        rm,
+		md:                  parse.Meta,
+		AppID:               option.Some(GenID()),
+		EnvID:               option.Some(GenID()),
+		Tracing:             TracingOptions{Endpoint: option.Some(fmt.Sprintf("http://localhost:%d/trace", mgr.RuntimePort))},
+		AuthKeys:            []config.EncoreAuthKey{authKey},
+		Gateways:            GatewayOptions{Configs: gateways},
+		DefinedSecrets:      secrets,
+		SvcConfigs:          cfg.Configs,
+		IncludeMeta:         bld.NeedsMeta(),
+		MetaPath:            option.Some(filepath.Join(p.TempDir, "meta.pb")),
+		RuntimeConfigPath:   option.Some(filepath.Join(p.TempDir, "runtime_config.pb")),
+		RuntimeConfigFormat: runtimeConfigFormat(bld.UseNewRuntimeConfig()),
+		VCS:                 option.Some(vcsRevision),
 	}
-	procConf, err := configGen.AllInOneProc(bld.UseNewRuntimeConfig())
+	procConf, err := configGen.AllInOneProc()
 	if err != nil {
 		return nil, err
 	}
-	procEnv, err := configGen.ProcEnvs(procConf, bld.UseNewRuntimeConfig())
+	procEnv, err := configGen.ProcEnvs(procConf)
 	if err != nil {
 		return nil, errors.Wrap(err, "compute proc envs")
 	}
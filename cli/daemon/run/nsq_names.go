@@ -3,6 +3,7 @@ package run
 import (
 	"encoding/hex"
 	"regexp"
+	"strings"
 
 	"golang.org/x/crypto/sha3"
 )
@@ -23,6 +24,16 @@ func hashNSQName(name string) string {
 	return hex.EncodeToString(hash[:])
 }
 
+// nsqCloudName returns the NSQ name of the topic or channel with the given
+// Encore name, applying the name template if one is given.
+// See config.NSQProvider.NameTemplate for the template syntax.
+func nsqCloudName(template, envName, name string) string {
+	if template != "" {
+		name = strings.NewReplacer("{env}", envName, "{name}", name).Replace(template)
+	}
+	return ensureValidNSQName(name)
+}
+
 // ensureValidNSQName returns the name if it's valid, otherwise returns a hashed version.
 func ensureValidNSQName(name string) string {
 	if isValidNSQName(name) {
@@ -11,6 +11,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -160,6 +161,35 @@ func (pg *ProcGroup) Close() {
 	pg.procMu.Unlock()
 
 	wg.Wait()
+
+	if pg.ConfigGen != nil {
+		for _, path := range pg.ConfigGen.TempFiles() {
+			_ = os.Remove(path)
+		}
+	}
+}
+
+// closeProcsOnPorts closes the processes in the group listening on
+// any of the given ports, and waits for them to exit.
+func (pg *ProcGroup) closeProcsOnPorts(ports []uint16) {
+	if len(ports) == 0 {
+		return
+	}
+
+	var wg sync.WaitGroup
+	pg.procMu.Lock()
+	for _, p := range pg.allProcesses {
+		if p.Started.Load() && slices.Contains(ports, p.listenAddr.Port()) {
+			wg.Add(1)
+			go func(p *Proc) {
+				p.Close()
+				wg.Done()
+			}(p)
+		}
+	}
+	pg.procMu.Unlock()
+
+	wg.Wait()
 }
 
 // Kill kills all the processes in the group.
@@ -455,7 +485,12 @@ func (p *Proc) Close() {
 		p.Kill()
 	}
 
-	timer := time.NewTimer(gracefulShutdownTime + (500 * time.Millisecond))
+	shutdownTime := gracefulShutdownTime
+	if p.group.ConfigGen != nil {
+		shutdownTime = p.group.ConfigGen.GracefulShutdownTime()
+	}
+
+	timer := time.NewTimer(shutdownTime + (500 * time.Millisecond))
 	defer timer.Stop()
 
 	select {
@@ -0,0 +1,106 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net"
+	"os"
+	"os/exec"
+	"slices"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/rs/zerolog"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// helperListenAddrEnv makes the test binary act as a proc
+// listening on the given address, see TestHelperProcListen.
+const helperListenAddrEnv = "ENCORE_TEST_HELPER_LISTEN_ADDR"
+
+// TestHelperProcListen isn't a real test. It's run as a subprocess
+// standing in for an app proc, listening until it's interrupted.
+func TestHelperProcListen(t *testing.T) {
+	addr := os.Getenv(helperListenAddrEnv)
+	if addr == "" {
+		return
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		os.Exit(1)
+	}
+	defer ln.Close()
+	time.Sleep(time.Minute)
+	os.Exit(0)
+}
+
+func TestProcGroup_ReloadWithPinnedPort(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	proxy := newTestProxy(c)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, qt.IsNil)
+	port := uint16(ln.Addr().(*net.TCPAddr).Port)
+	c.Assert(ln.Close(), qt.IsNil)
+
+	md := &meta.Data{Svcs: []*meta.Service{{Name: "foo"}, {Name: "bar"}}}
+	svcPorts := map[string]uint16{"foo": port}
+	newGen := func() *RuntimeConfigGenerator {
+		gen := newTestGenerator(md)
+		gen.SvcPorts = svcPorts
+		return gen
+	}
+
+	// Start the previous generation's proc for the service on the pinned port.
+	gen := newGen()
+	services, _, err := gen.ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+	gen.ReleaseAddresses()
+
+	prev := newProcGroup(procGroupOptions{
+		Ctx:       ctx,
+		ProcID:    "prev",
+		Run:       &Run{log: zerolog.Nop()},
+		Meta:      md,
+		ConfigGen: gen,
+	})
+	listenAddr := services["foo"].ListenAddr
+	proc, err := prev.newProc("foo", listenAddr)
+	c.Assert(err, qt.IsNil)
+	proc.cmd = exec.CommandContext(ctx, os.Args[0], "-test.run=^TestHelperProcListen$")
+	proc.cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%s", helperListenAddrEnv, listenAddr))
+	prev.Services["foo"] = proc
+	c.Assert(prev.Start(), qt.IsNil)
+	defer prev.Kill()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		conn, err := net.Dial("tcp", listenAddr.String())
+		if err == nil {
+			_ = conn.Close()
+			break
+		} else if time.Now().After(deadline) {
+			c.Fatalf("helper proc never listened on %s: %v", listenAddr, err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	// The new config can be validated while the previous proc listens on the pinned port,
+	// but the port can't be reserved.
+	c.Assert(newGen().DryRun(), qt.IsNil)
+	_, _, err = newGen().ProcPerService(proxy)
+	c.Assert(err, qt.ErrorMatches, fmt.Sprintf(`port %d configured for service "foo" is not available: .*`, port))
+
+	// Once the procs on the pinned ports are closed it can be.
+	prev.closeProcsOnPorts(slices.Collect(maps.Values(svcPorts)))
+	next := newGen()
+	services, _, err = next.ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+	c.Assert(services["foo"].ListenAddr, qt.Equals, listenAddr)
+	next.ReleaseAddresses()
+}
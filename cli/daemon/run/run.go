@@ -3,11 +3,14 @@ package run
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/binary"
+	"encoding/json"
 	"fmt"
+	"maps"
 	"net"
 	"net/http"
 	"net/netip"
@@ -107,6 +110,10 @@ type StartParams struct {
 
 	// ScrubSensitiveData enables scrubbing of sensitive data in local traces.
 	ScrubSensitiveData bool
+
+	// SvcPorts pins services to fixed ports across restarts, keyed by service name.
+	// It only applies when services run in their own procs.
+	SvcPorts map[string]uint16
 }
 
 // BrowserMode specifies how to open the browser when starting 'encore run'.
@@ -489,6 +496,7 @@ func (r *Run) buildAndStart(ctx context.Context, tracker *optracker.OpTracker, i
 		WorkingDir:     r.Params.WorkingDir,
 		IsReload:       isReload,
 		Experiments:    expSet,
+		VCS:            option.Some(vcsRevision),
 	})
 	if err != nil {
 		tracker.Fail(startOp, err)
@@ -538,6 +546,7 @@ type StartProcGroupParams struct {
 	WorkingDir     string
 	IsReload       bool
 	Experiments    *experiments.Set
+	VCS            option.Option[vcs.Status]
 }
 
 const gracefulShutdownTime = 10 * time.Second
@@ -580,45 +589,84 @@ func (r *Run) StartProcGroup(params *StartProcGroupParams) (p *ProcGroup, err er
 		}
 	}
 
+	// The generator's format applies to the all-in-one proc.
+	// Procs for individual services and gateways use their entrypoint's format.
+	rtFormat := RuntimeConfigLegacy
+	if isSingleProc(params.Outputs) {
+		rtFormat = runtimeConfigFormat(params.Outputs[0].GetEntrypoints()[0].UseRuntimeConfigV2)
+	}
+
+	// Keep resource ids stable across restarts.
+	prev := r.ProcGroup()
+	var priorRIDs map[string]string
+	if prev != nil {
+		priorRIDs = prev.ConfigGen.RIDs()
+	}
+
 	authKey := genAuthKey()
+	newConfigGen := func() *RuntimeConfigGenerator {
+		return &RuntimeConfigGenerator{
+			app:                 r.App,
+			infraManager:        r.ResourceManager,
+			md:                  params.Meta,
+			AppID:               option.Some(r.ID),
+			EnvID:               option.Some(pid),
+			Tracing:             TracingOptions{Endpoint: option.Some(fmt.Sprintf("http://localhost:%d/trace", r.Mgr.RuntimePort))},
+			AuthKeys:            []config.EncoreAuthKey{authKey},
+			Gateways:            GatewayOptions{Configs: gateways},
+			DefinedSecrets:      params.Secrets,
+			SvcConfigs:          params.ServiceConfigs,
+			DeployID:            option.Some(fmt.Sprintf("run_%s", xid.New().String())),
+			IncludeMeta:         r.Builder.NeedsMeta(),
+			MetaPath:            metaPath,
+			MaxMetaEnvSize:      defaultMaxEnvSize,
+			RuntimeConfigPath:   runtimeConfigPath,
+			LogLevel:            r.Params.LogLevel,
+			PriorRIDs:           priorRIDs,
+			RuntimeConfigFormat: rtFormat,
+			SvcPorts:            r.Params.SvcPorts,
+			VCS:                 params.VCS,
+			Log:                 r.log,
+		}
+	}
+
+	if prev != nil {
+		// Validate the new config before touching the previous procs,
+		// so a broken config doesn't take down the running app.
+		if err := newConfigGen().DryRun(); err != nil {
+			return nil, err
+		}
+
+		// The previous procs listen on the pinned ports until they exit,
+		// so they must be stopped before the ports can be reserved again.
+		prev.closeProcsOnPorts(slices.Collect(maps.Values(r.Params.SvcPorts)))
+	}
+
 	p = newProcGroup(procGroupOptions{
-		ProcID:  pid,
-		Run:     r,
-		AuthKey: authKey,
-		ConfigGen: &RuntimeConfigGenerator{
-			app:               r.App,
-			infraManager:      r.ResourceManager,
-			md:                params.Meta,
-			AppID:             option.Some(r.ID),
-			EnvID:             option.Some(pid),
-			TraceEndpoint:     option.Some(fmt.Sprintf("http://localhost:%d/trace", r.Mgr.RuntimePort)),
-			AuthKey:           authKey,
-			Gateways:          gateways,
-			DefinedSecrets:    params.Secrets,
-			SvcConfigs:        params.ServiceConfigs,
-			DeployID:          option.Some(fmt.Sprintf("run_%s", xid.New().String())),
-			IncludeMeta:       r.Builder.NeedsMeta(),
-			MetaPath:          metaPath,
-			RuntimeConfigPath: runtimeConfigPath,
-			LogLevel:          r.Params.LogLevel,
-		},
+		ProcID:      pid,
+		Run:         r,
+		AuthKey:     authKey,
+		ConfigGen:   newConfigGen(),
 		Experiments: params.Experiments,
 		Meta:        params.Meta,
 		Ctx:         params.Ctx,
 		WorkingDir:  params.WorkingDir,
 		Logger:      params.Logger,
 	})
+	// Release the addresses reserved while generating the proc configs
+	// if starting the procs fails before they're released below.
+	defer p.ConfigGen.ReleaseAddresses()
 
 	if isSingleProc(params.Outputs) {
 		entrypoint := params.Outputs[0].GetEntrypoints()[0]
 
-		conf, err := p.ConfigGen.AllInOneProc(entrypoint.UseRuntimeConfigV2)
+		conf, err := p.ConfigGen.AllInOneProc()
 		if err != nil {
 			return nil, err
 		}
 
 		// Generate the environmental variables for the process
-		procEnv, err := p.ConfigGen.ProcEnvs(conf, entrypoint.UseRuntimeConfigV2)
+		procEnv, err := p.ConfigGen.ProcEnvs(conf)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to generate environment variables")
 		}
@@ -652,6 +700,7 @@ func (r *Run) StartProcGroup(params *StartProcGroupParams) (p *ProcGroup, err er
 		for _, o := range params.Outputs {
 			for _, ep := range o.GetEntrypoints() {
 				cmd := ep.Cmd.Expand(o.GetArtifactDir())
+				epFormat := option.Some(runtimeConfigFormat(ep.UseRuntimeConfigV2))
 				// create a process for each service
 				for _, svcName := range ep.Services {
 					// Generate the environmental variables for the process
@@ -659,7 +708,8 @@ func (r *Run) StartProcGroup(params *StartProcGroupParams) (p *ProcGroup, err er
 					if !ok {
 						return nil, errors.Newf("unknown service %q", svcName)
 					}
-					procEnv, err := p.ConfigGen.ProcEnvs(procConf, ep.UseRuntimeConfigV2)
+					procConf.RuntimeConfigFormat = epFormat
+					procEnv, err := p.ConfigGen.ProcEnvs(procConf)
 					if err != nil {
 						return nil, errors.Wrap(err, "failed to generate environment variables")
 					}
@@ -677,8 +727,9 @@ func (r *Run) StartProcGroup(params *StartProcGroupParams) (p *ProcGroup, err er
 					if !ok {
 						return nil, errors.Newf("unknown gateway %q", gwName)
 					}
+					procConf.RuntimeConfigFormat = epFormat
 
-					procEnv, err := p.ConfigGen.ProcEnvs(procConf, ep.UseRuntimeConfigV2)
+					procEnv, err := p.ConfigGen.ProcEnvs(procConf)
 					if err != nil {
 						return nil, errors.Wrap(err, "failed to generate environment variables")
 					}
@@ -694,6 +745,9 @@ func (r *Run) StartProcGroup(params *StartProcGroupParams) (p *ProcGroup, err er
 		}
 	}
 
+	// Release the addresses reserved for the processes so they can listen on them.
+	p.ConfigGen.ReleaseAddresses()
+
 	// Start the processes of the application
 	if err := p.Start(); err != nil {
 		return nil, err
@@ -820,11 +874,24 @@ func encodeSecretsEnv(secrets map[string]string) string {
 		buf.WriteString(base64.RawURLEncoding.EncodeToString([]byte(secrets[k])))
 	}
 
-	gzipped := gzipBytes(buf.Bytes())
+	// The default level is always valid.
+	gzipped, _ := gzipBytes(buf.Bytes(), gzip.DefaultCompression)
 	str := "gzip:" + base64.StdEncoding.EncodeToString(gzipped)
 	return str
 }
 
+// encodeSecretsEnvJSON encodes secrets as "json:" followed by
+// the base64-encoded JSON object of secret values, keyed by name.
+func encodeSecretsEnvJSON(secrets map[string]string) string {
+	if len(secrets) == 0 {
+		return ""
+	}
+
+	// Marshalling a map of strings can't fail.
+	data, _ := json.Marshal(secrets)
+	return "json:" + base64.StdEncoding.EncodeToString(data)
+}
+
 func usesSecrets(md *meta.Data) bool {
 	for _, pkg := range md.Pkgs {
 		if len(pkg.Secrets) > 0 {
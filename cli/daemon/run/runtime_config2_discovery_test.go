@@ -0,0 +1,362 @@
+package run
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/fns"
+	"encr.dev/pkg/rtconfgen"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	runtimev1 "encr.dev/proto/encore/runtime/v1"
+)
+
+func TestExternalHTTPDeps(t *testing.T) {
+	c := qt.New(t)
+
+	newGen := func(deps map[string]ExternalHTTPDependency) *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "svc"}}})
+		gen.ExternalHTTPDeps = deps
+		return gen
+	}
+
+	proc, err := newGen(map[string]ExternalHTTPDependency{
+		"payments": {
+			BaseURL:         "https://api.payments.example.com/v1",
+			MaxConns:        20,
+			MaxIdleConns:    5,
+			IdleConnTimeout: 90 * time.Second,
+			ConnectTimeout:  5 * time.Second,
+			RequestTimeout:  30 * time.Second,
+		},
+		"geo": {BaseURL: "http://geo.internal:8080"},
+	}).AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	deps := proc.Runtime.MustGet().Deployment.ExternalHttpDependencies
+	c.Assert(deps, qt.HasLen, 2)
+
+	// Unset limits and timeouts keep the runtime defaults.
+	c.Assert(deps[0].Name, qt.Equals, "geo")
+	c.Assert(deps[0].BaseUrl, qt.Equals, "http://geo.internal:8080")
+	c.Assert(deps[0].MaxConns, qt.Equals, int32(0))
+	c.Assert(deps[0].MaxIdleConns, qt.Equals, int32(0))
+	c.Assert(deps[0].IdleConnTimeout, qt.IsNil)
+	c.Assert(deps[0].ConnectTimeout, qt.IsNil)
+	c.Assert(deps[0].RequestTimeout, qt.IsNil)
+
+	c.Assert(deps[1].Name, qt.Equals, "payments")
+	c.Assert(deps[1].BaseUrl, qt.Equals, "https://api.payments.example.com/v1")
+	c.Assert(deps[1].MaxConns, qt.Equals, int32(20))
+	c.Assert(deps[1].MaxIdleConns, qt.Equals, int32(5))
+	c.Assert(deps[1].IdleConnTimeout.AsDuration(), qt.Equals, 90*time.Second)
+	c.Assert(deps[1].ConnectTimeout.AsDuration(), qt.Equals, 5*time.Second)
+	c.Assert(deps[1].RequestTimeout.AsDuration(), qt.Equals, 30*time.Second)
+
+	tests := []struct {
+		name    string
+		dep     ExternalHTTPDependency
+		wantErr string
+	}{
+		{
+			name:    "relative url",
+			dep:     ExternalHTTPDependency{BaseURL: "/v1"},
+			wantErr: `invalid external http dependency "payments": base url "/v1" must be an absolute http\(s\) url`,
+		},
+		{
+			name:    "non-http url",
+			dep:     ExternalHTTPDependency{BaseURL: "ftp://payments.example.com"},
+			wantErr: `invalid external http dependency "payments": base url "ftp://payments.example.com" must be an absolute http\(s\) url`,
+		},
+		{
+			name:    "negative conns",
+			dep:     ExternalHTTPDependency{BaseURL: "https://payments.example.com", MaxConns: -1},
+			wantErr: `invalid external http dependency "payments": connection limits must not be negative`,
+		},
+		{
+			name:    "idle exceeds max",
+			dep:     ExternalHTTPDependency{BaseURL: "https://payments.example.com", MaxConns: 5, MaxIdleConns: 10},
+			wantErr: `invalid external http dependency "payments": max idle connections \(10\) must not exceed max connections \(5\)`,
+		},
+		{
+			name:    "negative timeout",
+			dep:     ExternalHTTPDependency{BaseURL: "https://payments.example.com", RequestTimeout: -time.Second},
+			wantErr: `invalid external http dependency "payments": timeouts must not be negative`,
+		},
+	}
+	for _, tt := range tests {
+		c.Run(tt.name, func(c *qt.C) {
+			_, err := newGen(map[string]ExternalHTTPDependency{"payments": tt.dep}).AllInOneProc()
+			c.Assert(err, qt.ErrorMatches, tt.wantErr)
+		})
+	}
+}
+
+func TestSvcLoadBalancing(t *testing.T) {
+	c := qt.New(t)
+
+	proxy := newTestProxy(c)
+
+	replicas := []string{"http://10.0.0.2:8080", "http://10.0.0.3:8080"}
+	newGen := func(policies map[string]string, replicaURLs map[string][]string) *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "foo"}, {Name: "bar"}}})
+		gen.SvcLoadBalancing = policies
+		gen.SvcReplicaURLs = replicaURLs
+		return gen
+	}
+
+	gen := newGen(map[string]string{"foo": "weighted-random"}, map[string][]string{"foo": replicas})
+	services, _, err := gen.ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+	gen.ReleaseAddresses()
+	sd := services["bar"].Runtime.MustGet().Deployment.ServiceDiscovery.Services
+	c.Assert(sd["foo"].GetLoadBalancing(), qt.Equals, runtimev1.ServiceDiscovery_LOAD_BALANCING_WEIGHTED_RANDOM)
+	c.Assert(sd["foo"].ReplicaUrls, qt.DeepEquals, replicas)
+
+	// Services without a policy use the runtime default.
+	sd = services["foo"].Runtime.MustGet().Deployment.ServiceDiscovery.Services
+	c.Assert(sd["bar"].LoadBalancing, qt.IsNil)
+	c.Assert(sd["bar"].ReplicaUrls, qt.HasLen, 0)
+
+	tests := []struct {
+		name        string
+		policies    map[string]string
+		replicaURLs map[string][]string
+		wantErr     string
+	}{
+		{
+			name:        "unknown policy",
+			policies:    map[string]string{"foo": "random"},
+			replicaURLs: map[string][]string{"foo": replicas},
+			wantErr:     `invalid load balancing for service "foo": unknown load balancing policy "random"`,
+		},
+		{
+			name:     "single endpoint",
+			policies: map[string]string{"foo": "round-robin"},
+			wantErr:  `invalid load balancing for service "foo": the service has a single endpoint`,
+		},
+		{
+			name:        "policy for unknown service",
+			policies:    map[string]string{"baz": "round-robin"},
+			replicaURLs: map[string][]string{"foo": replicas},
+			wantErr:     `load balancing configured for unknown service "baz"`,
+		},
+		{
+			name:        "replicas for unknown service",
+			replicaURLs: map[string][]string{"baz": replicas},
+			wantErr:     `replicas configured for unknown service "baz"`,
+		},
+		{
+			name:        "relative replica url",
+			replicaURLs: map[string][]string{"foo": {"/foo"}},
+			wantErr:     `invalid replica url "/foo" for service "foo": must be an absolute http or https url`,
+		},
+	}
+	for _, tt := range tests {
+		c.Run(tt.name, func(c *qt.C) {
+			gen := newGen(tt.policies, tt.replicaURLs)
+			_, _, err := gen.ProcPerService(proxy)
+			c.Assert(err, qt.ErrorMatches, tt.wantErr)
+		})
+	}
+}
+
+func TestServiceDependencies(t *testing.T) {
+	c := qt.New(t)
+
+	gen := newTestGenerator(&meta.Data{
+		Svcs: []*meta.Service{
+			{Name: "orders", Databases: []string{"orders"}, Buckets: []*meta.BucketUsage{{Bucket: "invoices"}}},
+			{Name: "payments", Databases: []string{"payments"}},
+			{Name: "emails"},
+		},
+		Pkgs: []*meta.Package{
+			{RelPath: "orders", ServiceName: "orders", Secrets: []string{"OrdersKey"},
+				RpcCalls: []*meta.QualifiedName{{Pkg: "payments", Name: "Charge"}}},
+			{RelPath: "payments", ServiceName: "payments", Secrets: []string{"StripeKey"}},
+			{RelPath: "emails", ServiceName: "emails", Secrets: []string{"SendgridKey"}},
+		},
+		PubsubTopics: []*meta.PubSubTopic{{
+			Name:          "order-created",
+			Publishers:    []*meta.PubSubTopic_Publisher{{ServiceName: "orders"}},
+			Subscriptions: []*meta.PubSubTopic_Subscription{{Name: "send-confirmation", ServiceName: "emails"}},
+		}},
+		CacheClusters: []*meta.CacheCluster{{Name: "sessions", Keyspaces: []*meta.CacheCluster_Keyspace{{Service: "payments"}}}},
+	})
+
+	deps, err := gen.ServiceDependencies("orders", false)
+	c.Assert(err, qt.IsNil)
+	c.Assert(deps, qt.DeepEquals, rtconfgen.ResourceSet{
+		Databases: []string{"orders"},
+		Topics:    []string{"order-created"},
+		Buckets:   []string{"invoices"},
+		Secrets:   []string{"OrdersKey"},
+	})
+
+	// Including called services adds the resources of payments,
+	// but not of emails, which is only reached through Pub/Sub.
+	deps, err = gen.ServiceDependencies("orders", true)
+	c.Assert(err, qt.IsNil)
+	c.Assert(deps, qt.DeepEquals, rtconfgen.ResourceSet{
+		Databases: []string{"orders", "payments"},
+		Topics:    []string{"order-created"},
+		Caches:    []string{"sessions"},
+		Buckets:   []string{"invoices"},
+		Secrets:   []string{"OrdersKey", "StripeKey"},
+	})
+
+	deps, err = gen.ServiceDependencies("emails", false)
+	c.Assert(err, qt.IsNil)
+	c.Assert(deps.Subscriptions, qt.DeepEquals, []rtconfgen.Subscription{{Topic: "order-created", Name: "send-confirmation"}})
+
+	_, err = gen.ServiceDependencies("unknown", false)
+	c.Assert(err, qt.ErrorMatches, `unknown service "unknown"`)
+}
+
+func TestAllInOneProc_ExternalServices(t *testing.T) {
+	c := qt.New(t)
+
+	gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "foo"}, {Name: "bar"}}})
+	gen.ExternalServices = map[string]string{"bar": "http://127.0.0.1:9500"}
+	_, conf, err := gen.AllInOneProcWithRuntimeConfig()
+	c.Assert(err, qt.IsNil)
+
+	hosted := fns.Map(conf.Deployment.HostedServices, func(svc *runtimev1.HostedService) string { return svc.Name })
+	c.Assert(hosted, qt.DeepEquals, []string{"foo"})
+
+	sd := conf.Deployment.ServiceDiscovery.Services
+	c.Assert(sd, qt.HasLen, 1)
+	c.Assert(sd["bar"].BaseUrl, qt.Equals, "http://127.0.0.1:9500")
+	c.Assert(sd["bar"].AuthMethods, qt.HasLen, 1)
+
+	gen = newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "foo"}}})
+	gen.ExternalServices = map[string]string{"bar": "http://127.0.0.1:9500"}
+	_, err = gen.AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `external service configured for unknown service "bar"`)
+}
+
+func TestSvcAuthMethods(t *testing.T) {
+	c := qt.New(t)
+
+	proxy := newTestProxy(c)
+
+	noop := []*runtimev1.ServiceAuth{{AuthMethod: &runtimev1.ServiceAuth_Noop{Noop: &runtimev1.ServiceAuth_NoopAuth{}}}}
+	newGen := func() *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "foo"}, {Name: "bar"}}})
+		gen.SvcAuthMethods = map[string][]*runtimev1.ServiceAuth{"foo": noop}
+		return gen
+	}
+	checkSD := func(sd map[string]*runtimev1.ServiceDiscovery_Location) {
+		c.Helper()
+		c.Assert(sd["foo"].AuthMethods, qt.HasLen, 1)
+		c.Assert(sd["foo"].AuthMethods[0].GetNoop(), qt.IsNotNil)
+		c.Assert(sd["bar"].AuthMethods, qt.HasLen, 1)
+		c.Assert(sd["bar"].AuthMethods[0].GetEncoreAuth(), qt.IsNotNil)
+	}
+
+	gen := newGen()
+	services, _, err := gen.ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+	gen.ReleaseAddresses()
+	checkSD(services["bar"].Runtime.MustGet().Deployment.ServiceDiscovery.Services)
+
+	gen = newGen()
+	_, services, _, err = gen.ProcPerServiceWithNewRuntimeConfig(proxy)
+	c.Assert(err, qt.IsNil)
+	gen.ReleaseAddresses()
+	checkSD(services["foo"].Runtime.MustGet().Deployment.ServiceDiscovery.Services)
+
+	// In the all-in-one proc only external services are in service discovery.
+	gen = newGen()
+	gen.ExternalServices = map[string]string{"foo": "http://foo.internal"}
+	proc, err := gen.AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	sd := proc.Runtime.MustGet().Deployment.ServiceDiscovery.Services
+	c.Assert(sd["foo"].AuthMethods[0].GetNoop(), qt.IsNotNil)
+
+	gen = newGen()
+	gen.SvcAuthMethods = map[string][]*runtimev1.ServiceAuth{"bar": {{}}}
+	_, err = gen.AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `invalid auth methods for service "bar": must be non-empty and each must have an auth method`)
+}
+
+func TestNonEncoreServices(t *testing.T) {
+	c := qt.New(t)
+
+	proxy := newTestProxy(c)
+
+	newGen := func() *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "foo"}}})
+		gen.NonEncoreServices = map[string]NonEncoreService{
+			"legacy": {BaseURL: "http://legacy.internal:8080"},
+		}
+		return gen
+	}
+	checkConf := func(conf *runtimev1.RuntimeConfig) {
+		c.Helper()
+		legacy := conf.Deployment.ServiceDiscovery.Services["legacy"]
+		c.Assert(legacy, qt.IsNotNil)
+		c.Assert(legacy.BaseUrl, qt.Equals, "http://legacy.internal:8080")
+		c.Assert(legacy.AuthMethods, qt.HasLen, 1)
+		c.Assert(legacy.AuthMethods[0].GetNoop(), qt.IsNotNil)
+		for _, svc := range conf.Deployment.HostedServices {
+			c.Assert(svc.Name, qt.Not(qt.Equals), "legacy")
+		}
+	}
+
+	gen := newGen()
+	services, _, err := gen.ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+	gen.ReleaseAddresses()
+	c.Assert(services, qt.HasLen, 1)
+	checkConf(services["foo"].Runtime.MustGet())
+
+	proc, err := newGen().AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	checkConf(proc.Runtime.MustGet())
+
+	gen = newGen()
+	gen.NonEncoreServices["foo"] = NonEncoreService{BaseURL: "http://foo.internal"}
+	_, err = gen.AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `non-encore service "foo" has the same name as an encore service`)
+
+	gen = newGen()
+	gen.NonEncoreServices["legacy"] = NonEncoreService{BaseURL: "legacy.internal"}
+	_, err = gen.AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `invalid base url "legacy.internal" for non-encore service "legacy": must be an absolute http or https url`)
+}
+
+func TestSvcConnections(t *testing.T) {
+	c := qt.New(t)
+
+	proxy := newTestProxy(c)
+
+	newGen := func(calls *appfile.ServiceCalls) *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "foo"}, {Name: "bar"}}})
+		gen.app = testApp{&appfile.File{ServiceCalls: calls}}
+		return gen
+	}
+
+	// The defaults are used if nothing is configured.
+	proc, err := newGen(nil).AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	conns := proc.Runtime.MustGet().Deployment.ServiceDiscovery.Connections
+	c.Assert(conns.MaxIdleConns, qt.Equals, int32(100))
+	c.Assert(conns.IdleConnTimeout.AsDuration(), qt.Equals, 90*time.Second)
+
+	maxIdle, timeout := 10, appfile.Duration(30*time.Second)
+	gen := newGen(&appfile.ServiceCalls{MaxIdleConns: &maxIdle, IdleConnTimeout: &timeout})
+	services, _, err := gen.ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+	gen.ReleaseAddresses()
+	for _, svc := range services {
+		conns := svc.Runtime.MustGet().Deployment.ServiceDiscovery.Connections
+		c.Assert(conns.MaxIdleConns, qt.Equals, int32(10))
+		c.Assert(conns.IdleConnTimeout.AsDuration(), qt.Equals, 30*time.Second)
+	}
+
+	maxIdle = 0
+	_, err = newGen(&appfile.ServiceCalls{MaxIdleConns: &maxIdle}).AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `invalid service_calls.max_idle_conns 0: must be positive`)
+}
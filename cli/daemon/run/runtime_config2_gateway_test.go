@@ -0,0 +1,515 @@
+package run
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/protobuf/proto"
+
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/fns"
+	"encr.dev/pkg/option"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	runtimev1 "encr.dev/proto/encore/runtime/v1"
+)
+
+func TestGatewayTimeouts(t *testing.T) {
+	c := qt.New(t)
+
+	newGen := func(cfg GatewayConfig) *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{
+			Svcs: []*meta.Service{{
+				Name: "foo",
+				Rpcs: []*meta.RPC{{Name: "Upload"}, {Name: "Export"}},
+			}},
+			Gateways: []*meta.Gateway{{EncoreName: "api-gateway"}},
+		})
+		gen.Gateways = GatewayOptions{Configs: map[string]GatewayConfig{"api-gateway": cfg}}
+		return gen
+	}
+
+	proc, err := newGen(GatewayConfig{
+		RequestTimeout: 30 * time.Second,
+		RouteTimeouts: map[string]time.Duration{
+			"foo.Upload": 5 * time.Minute,
+			"foo.Export": 2 * time.Minute,
+		},
+	}).AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	gw := proc.Runtime.MustGet().Infra.Resources.Gateways[0]
+	c.Assert(gw.RequestTimeout.AsDuration(), qt.Equals, 30*time.Second)
+	c.Assert(gw.RouteTimeouts, qt.HasLen, 2)
+	c.Assert(gw.RouteTimeouts[0].Service, qt.Equals, "foo")
+	c.Assert(gw.RouteTimeouts[0].Endpoint, qt.Equals, "Export")
+	c.Assert(gw.RouteTimeouts[0].Timeout.AsDuration(), qt.Equals, 2*time.Minute)
+	c.Assert(gw.RouteTimeouts[1].Endpoint, qt.Equals, "Upload")
+	c.Assert(gw.RouteTimeouts[1].Timeout.AsDuration(), qt.Equals, 5*time.Minute)
+
+	// Unset, requests never time out.
+	proc, err = newGen(GatewayConfig{}).AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	gw = proc.Runtime.MustGet().Infra.Resources.Gateways[0]
+	c.Assert(gw.RequestTimeout, qt.IsNil)
+	c.Assert(gw.RouteTimeouts, qt.HasLen, 0)
+
+	tests := []struct {
+		name    string
+		cfg     GatewayConfig
+		wantErr string
+	}{
+		{
+			name:    "negative default",
+			cfg:     GatewayConfig{RequestTimeout: -time.Second},
+			wantErr: `invalid request timeout -1s for gateway "api-gateway": must be positive`,
+		},
+		{
+			name:    "negative route",
+			cfg:     GatewayConfig{RouteTimeouts: map[string]time.Duration{"foo.Upload": -time.Second}},
+			wantErr: `invalid route timeout -1s for endpoint "foo.Upload": must be positive`,
+		},
+		{
+			name:    "zero route",
+			cfg:     GatewayConfig{RouteTimeouts: map[string]time.Duration{"foo.Upload": 0}},
+			wantErr: `invalid route timeout 0s for endpoint "foo.Upload": must be positive`,
+		},
+		{
+			name:    "unknown route",
+			cfg:     GatewayConfig{RouteTimeouts: map[string]time.Duration{"foo.Delete": time.Second}},
+			wantErr: `invalid route timeout endpoint "foo.Delete" for gateway "api-gateway": endpoint not found`,
+		},
+		{
+			name:    "malformed route",
+			cfg:     GatewayConfig{RouteTimeouts: map[string]time.Duration{"Upload": time.Second}},
+			wantErr: `invalid route timeout endpoint "Upload" for gateway "api-gateway": must be of the form service.Endpoint`,
+		},
+	}
+	for _, tt := range tests {
+		c.Run(tt.name, func(c *qt.C) {
+			_, err := newGen(tt.cfg).AllInOneProc()
+			c.Assert(err, qt.ErrorMatches, tt.wantErr)
+		})
+	}
+}
+
+func TestGatewayHostnames(t *testing.T) {
+	tests := []struct {
+		hostname string
+		wantErr  string
+	}{
+		{hostname: "localhost"},
+		{hostname: "api.example.com"},
+		{hostname: "*.example.com"},
+		{hostname: "127.0.0.1"},
+		{hostname: "::1"},
+		{hostname: "", wantErr: "must not be empty"},
+		{hostname: "https://api.example.com", wantErr: "must not include a scheme"},
+		{hostname: "api.example.com/v1", wantErr: "must not include a path"},
+		{hostname: "api_example.com", wantErr: "must be a valid DNS name or IP address"},
+		{hostname: "-api.example.com", wantErr: "must be a valid DNS name or IP address"},
+		{hostname: "api..example.com", wantErr: "must be a valid DNS name or IP address"},
+		{hostname: "api.*.example.com", wantErr: "must be a valid DNS name or IP address"},
+		{hostname: "localhost:4000", wantErr: "must be a valid DNS name or IP address"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.hostname, func(t *testing.T) {
+			c := qt.New(t)
+			gen := newTestGenerator(&meta.Data{
+				Svcs:     []*meta.Service{{Name: "foo"}},
+				Gateways: []*meta.Gateway{{EncoreName: "api-gateway"}},
+			})
+			gen.Gateways = GatewayOptions{
+				Configs: map[string]GatewayConfig{
+					"api-gateway": {BaseURL: "http://localhost:4000", Hostnames: []string{tt.hostname}},
+				},
+			}
+			_, err := gen.AllInOneProc()
+			if tt.wantErr == "" {
+				c.Assert(err, qt.IsNil)
+			} else {
+				c.Assert(err, qt.ErrorMatches, fmt.Sprintf(`invalid hostname %q for gateway "api-gateway": %s`, tt.hostname, tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestGatewayBaseURLs(t *testing.T) {
+	c := qt.New(t)
+
+	newGen := func(cfg GatewayConfig) *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{
+			Svcs:     []*meta.Service{{Name: "foo"}},
+			Gateways: []*meta.Gateway{{EncoreName: "api-gateway"}},
+		})
+		gen.Gateways = GatewayOptions{Configs: map[string]GatewayConfig{"api-gateway": cfg}}
+		return gen
+	}
+
+	proc, err := newGen(GatewayConfig{
+		BaseURLs:  []string{"https://example.com", "https://www.example.com"},
+		Hostnames: []string{"example.com", "*.example.com"},
+	}).AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	gw := proc.Runtime.MustGet().Infra.Resources.Gateways[0]
+	c.Assert(gw.BaseUrl, qt.Equals, "https://example.com")
+	c.Assert(gw.BaseUrls, qt.DeepEquals, []string{"https://example.com", "https://www.example.com"})
+
+	_, err = newGen(GatewayConfig{
+		BaseURLs:  []string{"https://example.com", "https://example.org"},
+		Hostnames: []string{"example.com"},
+	}).AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `invalid base urls for gateway "api-gateway": base url "https://example.org" does not match any of the gateway's hostnames`)
+
+	_, err = newGen(GatewayConfig{
+		BaseURL:  "https://www.example.com",
+		BaseURLs: []string{"https://example.com", "https://www.example.com"},
+	}).AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `invalid base urls for gateway "api-gateway": base url "https://www.example.com" must be the first of the base urls`)
+}
+
+func TestGatewayRateLimit(t *testing.T) {
+	c := qt.New(t)
+
+	newGen := func(rl *appfile.RateLimit) *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{
+			Svcs:     []*meta.Service{{Name: "foo"}},
+			Gateways: []*meta.Gateway{{EncoreName: "api-gateway"}},
+		})
+		gen.app = testApp{appFile: &appfile.File{Gateways: map[string]appfile.Gateway{
+			"api-gateway": {RateLimit: rl},
+		}}}
+		return gen
+	}
+
+	window, burst := appfile.Duration(time.Minute), 20
+	proc, err := newGen(&appfile.RateLimit{Requests: 100, Window: &window, Burst: &burst, PerIP: true}).AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	rl := proc.Runtime.MustGet().Infra.Resources.Gateways[0].RateLimit
+	c.Assert(rl.Requests, qt.Equals, uint32(100))
+	c.Assert(rl.Window.AsDuration(), qt.Equals, time.Minute)
+	c.Assert(rl.Burst, qt.Equals, uint32(20))
+	c.Assert(rl.PerIp, qt.IsTrue)
+
+	// Unconfigured gateways are not rate limited.
+	proc, err = newGen(nil).AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	c.Assert(proc.Runtime.MustGet().Infra.Resources.Gateways[0].RateLimit, qt.IsNil)
+
+	_, err = newGen(&appfile.RateLimit{Requests: 0}).AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `invalid rate limit for gateway "api-gateway": requests must be positive, got 0`)
+
+	negative := -1
+	_, err = newGen(&appfile.RateLimit{Requests: 10, Burst: &negative}).AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `invalid rate limit for gateway "api-gateway": burst must be positive, got -1`)
+}
+
+func TestProcPerService_GatewayProxyRegistration(t *testing.T) {
+	c := qt.New(t)
+
+	proxy := newTestProxy(c)
+
+	newGen := func(gwCfg GatewayConfig) *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{
+			Svcs:     []*meta.Service{{Name: "foo"}},
+			Gateways: []*meta.Gateway{{EncoreName: "api-gateway"}},
+		})
+		gen.Gateways = GatewayOptions{Configs: map[string]GatewayConfig{"api-gateway": gwCfg}}
+		return gen
+	}
+
+	// checkProxied checks that requests to baseURL reach the gateway listening on addr.
+	checkProxied := func(baseURL string, addr netip.AddrPort) {
+		ln, err := net.Listen("tcp", addr.String())
+		c.Assert(err, qt.IsNil)
+		srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			_, _ = io.WriteString(w, req.URL.Path)
+		})}
+		go func() { _ = srv.Serve(ln) }()
+		defer srv.Close()
+
+		resp, err := http.Get(baseURL + "/hello")
+		c.Assert(err, qt.IsNil)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		c.Assert(err, qt.IsNil)
+		c.Assert(string(body), qt.Equals, "/hello")
+	}
+
+	gatewayBaseURL := func(conf *runtimev1.RuntimeConfig) string {
+		gws := conf.GetInfra().GetResources().GetGateways()
+		c.Assert(gws, qt.HasLen, 1)
+		return gws[0].BaseUrl
+	}
+
+	gen := newGen(GatewayConfig{})
+	_, gateways, err := gen.ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+	gen.ReleaseAddresses()
+	gw := gateways["api-gateway"]
+	baseURL := gatewayBaseURL(gw.Runtime.MustGet())
+	c.Assert(strings.HasSuffix(baseURL, "/gateway/api-gateway"), qt.IsTrue, qt.Commentf("base url: %s", baseURL))
+	checkProxied(baseURL, gw.ListenAddr)
+
+	gen = newGen(GatewayConfig{})
+	_, services, gateways, err := gen.ProcPerServiceWithNewRuntimeConfig(proxy)
+	c.Assert(err, qt.IsNil)
+	gen.ReleaseAddresses()
+	gw = gateways["api-gateway"]
+	baseURL = gatewayBaseURL(gw.Runtime.MustGet())
+	c.Assert(gatewayBaseURL(services["foo"].Runtime.MustGet()), qt.Equals, baseURL)
+	checkProxied(baseURL, gw.ListenAddr)
+
+	// An explicitly configured base url is kept.
+	_, gateways, err = newGen(GatewayConfig{BaseURL: "http://localhost:4000"}).ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+	c.Assert(gatewayBaseURL(gateways["api-gateway"].Runtime.MustGet()), qt.Equals, "http://localhost:4000")
+}
+
+func TestInternalGateway(t *testing.T) {
+	c := qt.New(t)
+
+	internalTag := []*meta.Selector{{Type: meta.Selector_TAG, Value: "internal"}}
+	public := map[string]*meta.RPC_ExposeOptions{"api-gateway": {}}
+	md := &meta.Data{
+		Svcs: []*meta.Service{{Name: "admin", Rpcs: []*meta.RPC{
+			{Name: "Public", Expose: public},
+			{Name: "Stats", Tags: internalTag},
+			{Name: "Both", Tags: internalTag, Expose: public},
+		}}},
+		Gateways: []*meta.Gateway{{EncoreName: "api-gateway"}},
+	}
+	privateHost := netip.MustParseAddr("127.0.0.2")
+	newGen := func() *RuntimeConfigGenerator {
+		gen := newTestGenerator(md)
+		gen.Gateways = GatewayOptions{
+			Internal: option.Some(InternalGatewayConfig{
+				Name:     "internal-gateway",
+				BindHost: option.Some(privateHost),
+			}),
+		}
+		return gen
+	}
+
+	gen := newGen()
+	proc, err := gen.AllInOneProc()
+	c.Assert(err, qt.IsNil)
+
+	// Only internal endpoints route through the internal gateway.
+	exposedOn := func(gwName string) []string {
+		var names []string
+		for _, rpc := range gen.md.Svcs[0].Rpcs {
+			if _, ok := rpc.Expose[gwName]; ok {
+				names = append(names, rpc.Name)
+			}
+		}
+		return names
+	}
+	c.Assert(exposedOn("internal-gateway"), qt.DeepEquals, []string{"Stats", "Both"})
+	c.Assert(exposedOn("api-gateway"), qt.DeepEquals, []string{"Public", "Both"})
+	c.Assert(md.Gateways, qt.HasLen, 1, qt.Commentf("the caller's metadata must not be modified"))
+
+	gws := proc.Runtime.MustGet().Infra.Resources.Gateways
+	c.Assert(gws, qt.HasLen, 2)
+	c.Assert(gws[0].EncoreName, qt.Equals, "api-gateway")
+	c.Assert(gws[0].Cors.GetUnsafeAllowAllOriginsWithCredentials(), qt.IsTrue)
+	c.Assert(gws[0].Cors.AllowPrivateNetworkAccess, qt.IsTrue)
+	c.Assert(gws[1].EncoreName, qt.Equals, "internal-gateway")
+	c.Assert(gws[1].Cors.DisableCredentials, qt.IsTrue)
+	c.Assert(gws[1].Cors.GetUnsafeAllowAllOriginsWithCredentials(), qt.IsFalse)
+	c.Assert(gws[1].Cors.AllowedOriginsWithoutCredentials.AllowedOrigins, qt.HasLen, 0)
+	c.Assert(gws[1].Cors.AllowPrivateNetworkAccess, qt.IsFalse)
+
+	// The internal gateway listens on its own bind host.
+	proxy := newTestProxy(c)
+	_, gateways, err := newGen().ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+	c.Assert(gateways["internal-gateway"].ListenAddr.Addr(), qt.Equals, privateHost)
+	c.Assert(gateways["api-gateway"].ListenAddr.Addr(), qt.Equals, netip.MustParseAddr("127.0.0.1"))
+
+	gen = newGen()
+	gen.Gateways.Internal = option.Some(InternalGatewayConfig{Name: "api-gateway"})
+	_, err = gen.AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `invalid internal gateway "api-gateway": a gateway with that name already exists`)
+}
+
+func TestGatewayCORSByEnvType(t *testing.T) {
+	c := qt.New(t)
+
+	gatewayCORSIn := func(envType runtimev1.Environment_Type, cloud runtimev1.Environment_Cloud, cors *appfile.CORS) *runtimev1.Gateway_CORS {
+		gen := newTestGenerator(&meta.Data{Gateways: []*meta.Gateway{{EncoreName: "api-gateway"}}})
+		gen.app = testApp{appFile: &appfile.File{GlobalCORS: cors}}
+		gen.EnvType = option.Some(envType)
+		gen.EnvCloud = option.Some(cloud)
+		proc, err := gen.AllInOneProc()
+		c.Assert(err, qt.IsNil)
+		gws := proc.Runtime.MustGet().Infra.Resources.Gateways
+		c.Assert(gws, qt.HasLen, 1)
+		return gws[0].Cors
+	}
+	gatewayCORS := func(envType runtimev1.Environment_Type, cors *appfile.CORS) *runtimev1.Gateway_CORS {
+		return gatewayCORSIn(envType, runtimev1.Environment_CLOUD_LOCAL, cors)
+	}
+
+	// Local development and tests stay permissive.
+	for _, envType := range []runtimev1.Environment_Type{runtimev1.Environment_TYPE_DEVELOPMENT, runtimev1.Environment_TYPE_TEST} {
+		local := gatewayCORS(envType, nil)
+		c.Assert(local.GetUnsafeAllowAllOriginsWithCredentials(), qt.IsTrue, qt.Commentf("env type %v", envType))
+		c.Assert(local.AllowedOriginsWithoutCredentials.AllowedOrigins, qt.DeepEquals, []string{"*"})
+		c.Assert(local.AllowPrivateNetworkAccess, qt.IsTrue)
+	}
+
+	// Deployed non-production environments get the production defaults.
+	for _, cors := range []*runtimev1.Gateway_CORS{
+		gatewayCORS(runtimev1.Environment_TYPE_EPHEMERAL, nil),
+		gatewayCORSIn(runtimev1.Environment_TYPE_DEVELOPMENT, runtimev1.Environment_CLOUD_AWS, nil),
+	} {
+		c.Assert(cors.GetUnsafeAllowAllOriginsWithCredentials(), qt.IsFalse)
+		c.Assert(cors.GetAllowedOrigins().GetAllowedOrigins(), qt.HasLen, 0)
+		c.Assert(cors.AllowPrivateNetworkAccess, qt.IsFalse)
+	}
+
+	// Production is locked down by default.
+	prod := gatewayCORS(runtimev1.Environment_TYPE_PRODUCTION, nil)
+	c.Assert(prod.GetUnsafeAllowAllOriginsWithCredentials(), qt.IsFalse)
+	c.Assert(prod.GetAllowedOrigins().GetAllowedOrigins(), qt.HasLen, 0)
+	c.Assert(prod.AllowedOriginsWithoutCredentials.AllowedOrigins, qt.DeepEquals, []string{"*"})
+	c.Assert(prod.AllowPrivateNetworkAccess, qt.IsFalse)
+
+	// Explicit app file config overrides the production defaults.
+	prod = gatewayCORS(runtimev1.Environment_TYPE_PRODUCTION, &appfile.CORS{
+		AllowOriginsWithCredentials:    []string{"https://app.example.com"},
+		AllowOriginsWithoutCredentials: []string{"https://*.example.com"},
+	})
+	c.Assert(prod.GetAllowedOrigins().GetAllowedOrigins(), qt.DeepEquals, []string{"https://app.example.com"})
+	c.Assert(prod.AllowedOriginsWithoutCredentials.AllowedOrigins, qt.DeepEquals, []string{"https://*.example.com"})
+	c.Assert(prod.AllowPrivateNetworkAccess, qt.IsFalse)
+}
+
+func TestGatewayMaxBodySize(t *testing.T) {
+	c := qt.New(t)
+
+	newGen := func(size *int64) *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{
+			Svcs:     []*meta.Service{{Name: "foo"}},
+			Gateways: []*meta.Gateway{{EncoreName: "api-gateway"}},
+		})
+		gen.app = testApp{appFile: &appfile.File{Gateways: map[string]appfile.Gateway{
+			"api-gateway": {MaxBodySize: size},
+		}}}
+		return gen
+	}
+	maxBodySize := func(size *int64) *uint64 {
+		proc, err := newGen(size).AllInOneProc()
+		c.Assert(err, qt.IsNil)
+		return proc.Runtime.MustGet().Infra.Resources.Gateways[0].MaxRequestBodySize
+	}
+
+	c.Assert(maxBodySize(proto.Int64(10<<20)), qt.DeepEquals, proto.Uint64(10<<20))
+	// Zero means unlimited, which is distinct from the runtime default.
+	c.Assert(maxBodySize(proto.Int64(0)), qt.DeepEquals, proto.Uint64(0))
+	c.Assert(maxBodySize(nil), qt.IsNil)
+
+	_, err := newGen(proto.Int64(-1)).AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `invalid max body size for gateway "api-gateway": must not be negative, got -1`)
+}
+
+func TestProcPerService_OnlyGateways(t *testing.T) {
+	c := qt.New(t)
+
+	proxy := newTestProxy(c)
+
+	newGen := func(only ...string) *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{
+			Svcs: []*meta.Service{{Name: "foo"}, {Name: "bar"}},
+			Gateways: []*meta.Gateway{
+				{EncoreName: "api-gateway"},
+				{EncoreName: "admin"},
+				{EncoreName: "webhooks"},
+			},
+		})
+		gen.Gateways = GatewayOptions{Only: only}
+		return gen
+	}
+
+	gen := newGen("admin")
+	services, gateways, err := gen.ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+	gen.ReleaseAddresses()
+	c.Assert(slices.Sorted(maps.Keys(gateways)), qt.DeepEquals, []string{"admin"})
+	c.Assert(slices.Sorted(maps.Keys(services)), qt.DeepEquals, []string{"bar", "foo"})
+	adminConf := gateways["admin"].Runtime.MustGet()
+	adminGw, ok := fns.Find(adminConf.Infra.Resources.Gateways, func(gw *runtimev1.Gateway) bool { return gw.EncoreName == "admin" })
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(adminConf.Deployment.HostedGateways, qt.DeepEquals, []string{adminGw.Rid})
+	sd := services["foo"].Runtime.MustGet().Deployment.ServiceDiscovery
+	c.Assert(slices.Sorted(maps.Keys(sd.Services)), qt.DeepEquals, []string{"bar", "foo"})
+
+	gen = newGen("admin")
+	_, services, gateways, err = gen.ProcPerServiceWithNewRuntimeConfig(proxy)
+	c.Assert(err, qt.IsNil)
+	gen.ReleaseAddresses()
+	c.Assert(slices.Sorted(maps.Keys(gateways)), qt.DeepEquals, []string{"admin"})
+	c.Assert(slices.Sorted(maps.Keys(services)), qt.DeepEquals, []string{"bar", "foo"})
+
+	// Without an allowlist every gateway gets a proc.
+	gen = newGen()
+	_, gateways, err = gen.ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+	gen.ReleaseAddresses()
+	c.Assert(gateways, qt.HasLen, 3)
+
+	_, _, err = newGen("unknown").ProcPerService(proxy)
+	c.Assert(err, qt.ErrorMatches, `unknown gateway "unknown" in gateway allowlist`)
+}
+
+func TestGatewayVariants(t *testing.T) {
+	c := qt.New(t)
+
+	newGen := func(variant string) *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{
+			Svcs:     []*meta.Service{{Name: "foo"}},
+			Gateways: []*meta.Gateway{{EncoreName: "api-gateway"}},
+		})
+		gen.Gateways = GatewayOptions{
+			Configs: map[string]GatewayConfig{"api-gateway": {
+				BaseURLs:  []string{"https://example.com:8443/api", "https://www.example.com"},
+				Hostnames: []string{"example.com", "*.example.com"},
+				Variant:   variant,
+			}},
+		}
+		return gen
+	}
+	gateway := func(variant string) *runtimev1.Gateway {
+		proc, err := newGen(variant).AllInOneProc()
+		c.Assert(err, qt.IsNil)
+		return proc.Runtime.MustGet().Infra.Resources.Gateways[0]
+	}
+
+	blue, green := gateway("blue"), gateway("green")
+	c.Assert(blue.GetVariant(), qt.Equals, "blue")
+	c.Assert(blue.BaseUrl, qt.Equals, "https://blue.example.com:8443/api")
+	c.Assert(blue.BaseUrls, qt.DeepEquals, []string{"https://blue.example.com:8443/api", "https://blue.www.example.com"})
+	c.Assert(blue.Hostnames, qt.DeepEquals, []string{"blue.example.com", "*.blue.example.com", "example.com", "*.example.com"})
+
+	c.Assert(green.GetVariant(), qt.Equals, "green")
+	c.Assert(green.BaseUrl, qt.Equals, "https://green.example.com:8443/api")
+	c.Assert(green.Hostnames, qt.DeepEquals, []string{"green.example.com", "*.green.example.com", "example.com", "*.example.com"})
+	c.Assert(green.Rid, qt.Not(qt.Equals), blue.Rid)
+
+	// Without a variant the gateway is configured as-is.
+	gw := gateway("")
+	c.Assert(gw.Variant, qt.IsNil)
+	c.Assert(gw.BaseUrl, qt.Equals, "https://example.com:8443/api")
+	c.Assert(gw.Hostnames, qt.DeepEquals, []string{"example.com", "*.example.com"})
+
+	_, err := newGen("blue.green").AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `invalid variant "blue.green" for gateway "api-gateway": must be a valid DNS label`)
+}
@@ -0,0 +1,206 @@
+package run
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/option"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	runtimev1 "encr.dev/proto/encore/runtime/v1"
+)
+
+func TestErrorFormat(t *testing.T) {
+	c := qt.New(t)
+
+	newGen := func(format option.Option[string], svcFormats map[string]string) *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "foo"}, {Name: "bar"}, {Name: "baz"}}})
+		gen.ErrorFormat = format
+		gen.SvcErrorFormats = svcFormats
+		return gen
+	}
+	errorFormats := func(gen *RuntimeConfigGenerator) map[string]*runtimev1.HostedService_ErrorFormat {
+		proc, err := gen.AllInOneProc()
+		c.Assert(err, qt.IsNil)
+		formats := make(map[string]*runtimev1.HostedService_ErrorFormat)
+		for _, svc := range proc.Runtime.MustGet().Deployment.HostedServices {
+			formats[svc.Name] = svc.ErrorFormat
+		}
+		return formats
+	}
+
+	// Per-service formats take precedence over the default format.
+	formats := errorFormats(newGen(option.Some("encore"), map[string]string{"foo": "problem+json"}))
+	c.Assert(formats["foo"], qt.IsNotNil)
+	c.Assert(*formats["foo"], qt.Equals, runtimev1.HostedService_ERROR_FORMAT_PROBLEM_JSON)
+	c.Assert(*formats["bar"], qt.Equals, runtimev1.HostedService_ERROR_FORMAT_ENCORE)
+	c.Assert(*formats["baz"], qt.Equals, runtimev1.HostedService_ERROR_FORMAT_ENCORE)
+
+	// Without a default only the configured services have a format.
+	formats = errorFormats(newGen(option.None[string](), map[string]string{"bar": "encore"}))
+	c.Assert(formats["foo"], qt.IsNil)
+	c.Assert(*formats["bar"], qt.Equals, runtimev1.HostedService_ERROR_FORMAT_ENCORE)
+	c.Assert(formats["baz"], qt.IsNil)
+
+	_, err := newGen(option.Some("xml"), nil).AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `invalid error format for service "(foo|bar|baz)": unknown error format "xml"`)
+	_, err = newGen(option.None[string](), map[string]string{"bar": "json"}).AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `invalid error format for service "bar": unknown error format "json"`)
+	_, err = newGen(option.None[string](), map[string]string{"unknown": "encore", "missing": "encore"}).AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `error format configured for unknown service "missing"`)
+}
+
+func TestEndpointTraceSampling(t *testing.T) {
+	c := qt.New(t)
+	t.Setenv("ENCORE_TRACE_SAMPLING_RATE", "")
+
+	newGen := func(traceEndpoint option.Option[string], sampling map[string]float64) *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{{
+			Name: "svc",
+			Rpcs: []*meta.RPC{{Name: "Health"}, {Name: "Checkout"}},
+		}}})
+		gen.Tracing = TracingOptions{
+			Endpoint:         traceEndpoint,
+			EndpointSampling: sampling,
+		}
+		return gen
+	}
+
+	proc, err := newGen(option.Some("http://localhost:4000/trace"), map[string]float64{
+		"svc.Health":   0,
+		"svc.Checkout": 1,
+	}).AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	tracing := proc.Runtime.MustGet().Deployment.Observability.Tracing
+	c.Assert(tracing, qt.HasLen, 1)
+	sampling := tracing[0].GetEncore().SamplingConfig
+	c.Assert(sampling, qt.HasLen, 3)
+	c.Assert(sampling[0].GetDefault(), qt.IsNotNil)
+	c.Assert(sampling[0].Rate, qt.Equals, 1.0)
+	c.Assert(sampling[1].GetEndpoint().Endpoint, qt.Equals, "Checkout")
+	c.Assert(sampling[1].GetEndpoint().Service, qt.Equals, "svc")
+	c.Assert(sampling[1].Rate, qt.Equals, 1.0)
+	c.Assert(sampling[2].GetEndpoint().Endpoint, qt.Equals, "Health")
+	c.Assert(sampling[2].Rate, qt.Equals, 0.0)
+
+	tests := []struct {
+		name          string
+		traceEndpoint option.Option[string]
+		sampling      map[string]float64
+		wantErr       string
+	}{
+		{
+			name:     "malformed endpoint",
+			sampling: map[string]float64{"Health": 0},
+			wantErr:  `invalid trace sampling endpoint "Health": must be of the form service.Endpoint`,
+		},
+		{
+			name:     "unknown endpoint",
+			sampling: map[string]float64{"svc.Metrics": 0},
+			wantErr:  `invalid trace sampling endpoint "svc.Metrics": endpoint not found`,
+		},
+		{
+			name:     "unknown service",
+			sampling: map[string]float64{"other.Health": 0},
+			wantErr:  `invalid trace sampling endpoint "other.Health": endpoint not found`,
+		},
+		{
+			name:     "rate out of range",
+			sampling: map[string]float64{"svc.Health": 1.5},
+			wantErr:  `invalid trace sampling rate 1.5 for endpoint "svc.Health": must be between 0 and 1`,
+		},
+		{
+			name:          "without trace endpoint",
+			traceEndpoint: option.None[string](),
+			sampling:      map[string]float64{"svc.Health": 0},
+			wantErr:       `endpoint trace sampling configured without a trace endpoint`,
+		},
+	}
+	for _, tt := range tests {
+		c.Run(tt.name, func(c *qt.C) {
+			// The overrides are validated whether or not tracing is enabled.
+			_, err := newGen(tt.traceEndpoint, tt.sampling).AllInOneProc()
+			c.Assert(err, qt.ErrorMatches, tt.wantErr)
+		})
+	}
+}
+
+func TestServiceLogConfig(t *testing.T) {
+	c := qt.New(t)
+
+	gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "bar"}, {Name: "foo"}}})
+	gen.app = testApp{appFile: &appfile.File{
+		LogLevel:  "info",
+		LogFormat: "console",
+		ServiceLogs: map[string]appfile.ServiceLog{
+			"foo": {Level: "debug", Format: "json"},
+		},
+	}}
+	proc, err := gen.AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	svcs := proc.Runtime.MustGet().Deployment.HostedServices
+	c.Assert(svcs, qt.HasLen, 2)
+
+	c.Assert(svcs[0].Name, qt.Equals, "bar")
+	c.Assert(svcs[0].GetLogConfig(), qt.Equals, "info")
+	c.Assert(svcs[0].GetLogFormat(), qt.Equals, runtimev1.HostedService_LOG_FORMAT_CONSOLE)
+
+	c.Assert(svcs[1].Name, qt.Equals, "foo")
+	c.Assert(svcs[1].GetLogConfig(), qt.Equals, "debug")
+	c.Assert(svcs[1].GetLogFormat(), qt.Equals, runtimev1.HostedService_LOG_FORMAT_JSON)
+}
+
+func TestMetricsExporter(t *testing.T) {
+	c := qt.New(t)
+
+	newGen := func(exp option.Option[MetricsExporter]) *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "foo"}}})
+		gen.Metrics = exp
+		gen.DefinedSecrets = map[string]string{"dd-key": "secret-key"}
+		return gen
+	}
+
+	// Without an exporter no provider is configured.
+	proc, err := newGen(option.None[MetricsExporter]()).AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	c.Assert(proc.Runtime.MustGet().Deployment.Observability.GetMetrics(), qt.HasLen, 0)
+
+	proc, err = newGen(option.Some(MetricsExporter{
+		Provider:     "prometheus",
+		Endpoint:     "https://prom.example.com/api/v1/write",
+		PushInterval: 15 * time.Second,
+	})).AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	providers := proc.Runtime.MustGet().Deployment.Observability.Metrics
+	c.Assert(providers, qt.HasLen, 1)
+	c.Assert(providers[0].CollectionInterval.AsDuration(), qt.Equals, 15*time.Second)
+	c.Assert(string(providers[0].GetPromRemoteWrite().RemoteWriteUrl.GetEmbedded()), qt.Equals,
+		"https://prom.example.com/api/v1/write")
+
+	proc, err = newGen(option.Some(MetricsExporter{
+		Provider:     "datadog",
+		Endpoint:     "datadoghq.eu",
+		APIKeySecret: "dd-key",
+	})).AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	dd := proc.Runtime.MustGet().Deployment.Observability.Metrics[0]
+	c.Assert(dd.CollectionInterval, qt.IsNil)
+	c.Assert(dd.GetDatadog().Site, qt.Equals, "datadoghq.eu")
+	c.Assert(string(dd.GetDatadog().ApiKey.GetEmbedded()), qt.Equals, "secret-key")
+
+	for _, tc := range []struct {
+		exp     MetricsExporter
+		wantErr string
+	}{
+		{MetricsExporter{Provider: "otlp", Endpoint: "localhost:4317"}, `unknown metrics provider "otlp".*`},
+		{MetricsExporter{Provider: "prometheus"}, `invalid prometheus metrics exporter: endpoint must not be empty`},
+		{MetricsExporter{Provider: "prometheus", Endpoint: "prom:9090"}, `invalid prometheus metrics exporter: endpoint "prom:9090" must be an http\(s\) URL`},
+		{MetricsExporter{Provider: "datadog", Endpoint: "datadoghq.com", APIKeySecret: "missing"}, `.*api key secret "missing" is not defined`},
+		{MetricsExporter{Provider: "prometheus", Endpoint: "http://prom", PushInterval: -time.Second}, `invalid metrics push interval -1s.*`},
+	} {
+		_, err := newGen(option.Some(tc.exp)).AllInOneProc()
+		c.Assert(err, qt.ErrorMatches, tc.wantErr)
+	}
+}
@@ -0,0 +1,371 @@
+package run
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"sync"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/pkg/option"
+	"encr.dev/pkg/svcproxy"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestFreeLocalhostAddress(t *testing.T) {
+	tests := []string{"127.0.0.1", "0.0.0.0", "::1", "::"}
+	for _, host := range tests {
+		t.Run(host, func(t *testing.T) {
+			c := qt.New(t)
+			addr := netip.MustParseAddr(host)
+			got, err := freeLocalhostAddress(addr)
+			if err != nil && addr.Is6() {
+				c.Skipf("ipv6 not supported: %v", err)
+			}
+			c.Assert(err, qt.IsNil)
+			c.Assert(got.Addr(), qt.Equals, addr)
+			c.Assert(got.Port(), qt.Not(qt.Equals), uint16(0))
+		})
+	}
+}
+
+func TestProcPerService_BindHost(t *testing.T) {
+	c := qt.New(t)
+
+	proxy := newTestProxy(c)
+
+	bindHost := netip.MustParseAddr("0.0.0.0")
+	gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "foo"}}})
+	gen.BindHost = option.Some(bindHost)
+	services, _, err := gen.ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+	c.Assert(services["foo"].ListenAddr.Addr(), qt.Equals, bindHost)
+}
+
+func TestFreeAddressInRange(t *testing.T) {
+	c := qt.New(t)
+	host := netip.MustParseAddr("127.0.0.1")
+
+	// Occupy a port and make sure it's skipped.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, qt.IsNil)
+	defer l.Close()
+	taken := uint16(l.Addr().(*net.TCPAddr).Port)
+	if taken == 65535 {
+		c.Skip("occupied port is at the end of the port range")
+	}
+
+	got, err := freeAddressInRange(host, PortRange{Min: taken, Max: taken + 1}, nil)
+	if err != nil {
+		c.Skipf("port %d is not free: %v", taken+1, err)
+	}
+	c.Assert(got, qt.Equals, netip.AddrPortFrom(host, taken+1))
+
+	// Exhausting the range is an error.
+	_, err = freeAddressInRange(host, PortRange{Min: taken, Max: taken + 1}, map[uint16]bool{taken + 1: true})
+	c.Assert(err, qt.ErrorMatches, `no free port in range \[\d+,\d+\]`)
+}
+
+func TestHealthCheckConfig(t *testing.T) {
+	c := qt.New(t)
+
+	newGen := func() *RuntimeConfigGenerator {
+		return newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "foo"}}})
+	}
+
+	proc, err := newGen().AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	svcs := proc.Runtime.MustGet().Deployment.HostedServices
+	c.Assert(svcs, qt.HasLen, 1)
+	c.Assert(svcs[0].GetHealthCheck().GetPath(), qt.Equals, "/__encore/healthz")
+
+	gen := newGen()
+	gen.Proxy.HealthCheckPath = option.Some("/ready")
+	proc, err = gen.AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	svcs = proc.Runtime.MustGet().Deployment.HostedServices
+	c.Assert(svcs[0].GetHealthCheck().GetPath(), qt.Equals, "/ready")
+
+	gen = newGen()
+	gen.Proxy.HealthCheckPath = option.Some("ready")
+	_, err = gen.AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `invalid health check path "ready": must start with a slash`)
+}
+
+func TestProcPerService_Protocol(t *testing.T) {
+	c := qt.New(t)
+
+	proxy := newTestProxy(c)
+
+	newGen := func() *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{
+			{Name: "grpc", Rpcs: []*meta.RPC{{
+				Name:  "Serve",
+				Proto: meta.RPC_RAW,
+				Tags:  []*meta.Selector{{Type: meta.Selector_TAG, Value: "grpc"}},
+			}}},
+			{Name: "web", Rpcs: []*meta.RPC{{Name: "Get", Proto: meta.RPC_REGULAR}}},
+			{Name: "secure"},
+		}})
+		gen.Proxy = ProxyOptions{SvcProtocols: map[string]svcproxy.Protocol{"secure": svcproxy.HTTP2TLS}}
+		return gen
+	}
+
+	services, _, err := newGen().ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+	c.Assert(services["grpc"].Protocol, qt.Equals, svcproxy.H2C)
+	c.Assert(services["web"].Protocol, qt.Equals, svcproxy.HTTP1)
+	c.Assert(services["secure"].Protocol, qt.Equals, svcproxy.HTTP2TLS)
+
+	gen := newGen()
+	_, services, _, err = gen.ProcPerServiceWithNewRuntimeConfig(proxy)
+	c.Assert(err, qt.IsNil)
+	gen.ReleaseAddresses()
+	c.Assert(services["grpc"].Protocol, qt.Equals, svcproxy.H2C)
+	c.Assert(services["web"].Protocol, qt.Equals, svcproxy.HTTP1)
+	c.Assert(services["secure"].Protocol, qt.Equals, svcproxy.HTTP2TLS)
+
+	// The proxy talks h2c to the gRPC service.
+	ln, err := net.Listen("tcp", services["grpc"].ListenAddr.String())
+	c.Assert(err, qt.IsNil)
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	srv := &http.Server{
+		Protocols: protocols,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			_, _ = io.WriteString(w, req.Proto)
+		}),
+	}
+	go func() { _ = srv.Serve(ln) }()
+	defer srv.Close()
+
+	sd := services["web"].Runtime.MustGet().Deployment.ServiceDiscovery
+	resp, err := http.Get(sd.Services["grpc"].BaseUrl + "/")
+	c.Assert(err, qt.IsNil)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(body), qt.Equals, "HTTP/2.0")
+
+	gen = newGen()
+	gen.Proxy.SvcProtocols = map[string]svcproxy.Protocol{"unknown": svcproxy.H2C}
+	_, _, err = gen.ProcPerService(proxy)
+	c.Assert(err, qt.ErrorMatches, `protocol configured for unknown service "unknown"`)
+}
+
+func TestReserveAddresses(t *testing.T) {
+	c := qt.New(t)
+
+	gen := newTestGenerator(&meta.Data{})
+	addrs, err := gen.ReserveAddresses(3)
+	c.Assert(err, qt.IsNil)
+	c.Assert(addrs, qt.HasLen, 3)
+
+	// The addresses are held until they're released.
+	for _, addr := range addrs {
+		_, err := net.Listen("tcp", addr.String())
+		c.Assert(err, qt.IsNotNil)
+	}
+
+	// Listen addresses are drawn from the reservation.
+	for _, want := range addrs {
+		got, err := gen.allocListenAddr()
+		c.Assert(err, qt.IsNil)
+		c.Assert(got, qt.Equals, want)
+	}
+
+	gen.ReleaseAddresses()
+	for _, addr := range addrs {
+		ln, err := net.Listen("tcp", addr.String())
+		c.Assert(err, qt.IsNil)
+		_ = ln.Close()
+	}
+}
+
+func TestReserveAddresses_RangeExhausted(t *testing.T) {
+	c := qt.New(t)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, qt.IsNil)
+	port := uint16(ln.Addr().(*net.TCPAddr).Port)
+	c.Assert(ln.Close(), qt.IsNil)
+
+	// Only one port is available, so reserving two fails
+	// and the port is released again.
+	gen := newTestGenerator(&meta.Data{})
+	gen.PortRange = option.Some(PortRange{Min: port, Max: port})
+	_, err = gen.ReserveAddresses(2)
+	c.Assert(err, qt.ErrorMatches, `failed to reserve 2 addresses: no free port in range.*`)
+
+	addrs, err := gen.ReserveAddresses(1)
+	c.Assert(err, qt.IsNil)
+	c.Assert(addrs, qt.HasLen, 1)
+	c.Assert(addrs[0], qt.Equals, netip.AddrPortFrom(netip.MustParseAddr("127.0.0.1"), port))
+	gen.ReleaseAddresses()
+}
+
+func TestProcPerService_ConcurrentPortAllocation(t *testing.T) {
+	c := qt.New(t)
+
+	proxy := newTestProxy(c)
+
+	// Generators sharing a port range must not hand out the same port,
+	// even when generating at the same time.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, qt.IsNil)
+	minPort := uint16(ln.Addr().(*net.TCPAddr).Port)
+	c.Assert(ln.Close(), qt.IsNil)
+	if minPort > 65535-200 {
+		c.Skip("free port is too close to the end of the port range")
+	}
+
+	const numGens, numSvcs = 8, 5
+	gens := make([]*RuntimeConfigGenerator, numGens)
+	for i := range gens {
+		var svcs []*meta.Service
+		for j := range numSvcs {
+			svcs = append(svcs, &meta.Service{Name: fmt.Sprintf("svc-%d-%d", i, j)})
+		}
+		gens[i] = newTestGenerator(&meta.Data{Svcs: svcs})
+		gens[i].PortRange = option.Some(PortRange{Min: minPort, Max: minPort + 200})
+	}
+
+	results := make([]map[string]*ProcConfig, numGens)
+	errs := make([]error, numGens)
+	var wg sync.WaitGroup
+	for i, gen := range gens {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _, errs[i] = gen.ProcPerService(proxy)
+		}()
+	}
+	wg.Wait()
+	for _, gen := range gens {
+		defer gen.ReleaseAddresses()
+	}
+
+	seen := make(map[netip.AddrPort]string)
+	for i, services := range results {
+		c.Assert(errs[i], qt.IsNil)
+		for name, proc := range services {
+			if other, ok := seen[proc.ListenAddr]; ok {
+				c.Fatalf("services %s and %s were both allocated %s", other, name, proc.ListenAddr)
+			}
+			seen[proc.ListenAddr] = name
+		}
+	}
+	c.Assert(seen, qt.HasLen, numGens*numSvcs)
+}
+
+func TestProcPerService_ReleasesAddressesOnError(t *testing.T) {
+	c := qt.New(t)
+
+	proxy := newTestProxy(c)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, qt.IsNil)
+	port := uint16(ln.Addr().(*net.TCPAddr).Port)
+	c.Assert(ln.Close(), qt.IsNil)
+
+	// Generation fails after the addresses are reserved.
+	gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "foo"}, {Name: "bar"}}})
+	gen.SvcPorts = map[string]uint16{"foo": port}
+	gen.SvcConfigs = map[string]string{"foo": "{}"}
+	_, _, _, err = gen.ProcPerServiceWithNewRuntimeConfig(proxy)
+	c.Assert(err, qt.ErrorMatches, `service configs not yet supported`)
+	c.Assert(gen.reserved, qt.HasLen, 0)
+
+	ln, err = net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	c.Assert(err, qt.IsNil)
+	c.Assert(ln.Close(), qt.IsNil)
+}
+
+func TestProcPerService_SvcPorts(t *testing.T) {
+	c := qt.New(t)
+
+	proxy := newTestProxy(c)
+
+	freePort := func() uint16 {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		c.Assert(err, qt.IsNil)
+		defer ln.Close()
+		return uint16(ln.Addr().(*net.TCPAddr).Port)
+	}
+	newGen := func(ports map[string]uint16) *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "foo"}, {Name: "bar"}}})
+		gen.SvcPorts = ports
+		return gen
+	}
+
+	port := freePort()
+	gen := newGen(map[string]uint16{"foo": port})
+	services, _, err := gen.ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+	c.Assert(services["foo"].ListenAddr, qt.Equals, netip.AddrPortFrom(netip.MustParseAddr("127.0.0.1"), port))
+	c.Assert(services["bar"].ListenAddr.Port(), qt.Not(qt.Equals), port)
+	gen.ReleaseAddresses()
+
+	// A taken port is an error rather than falling back to another port.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, qt.IsNil)
+	defer ln.Close()
+	taken := uint16(ln.Addr().(*net.TCPAddr).Port)
+	_, _, err = newGen(map[string]uint16{"foo": taken}).ProcPerService(proxy)
+	c.Assert(err, qt.ErrorMatches, fmt.Sprintf(`port %d configured for service "foo" is not available: .*address already in use`, taken))
+
+	_, _, err = newGen(map[string]uint16{"unknown": port}).ProcPerService(proxy)
+	c.Assert(err, qt.ErrorMatches, `port configured for unknown service "unknown"`)
+	_, _, err = newGen(map[string]uint16{"foo": port, "bar": port}).ProcPerService(proxy)
+	c.Assert(err, qt.ErrorMatches, fmt.Sprintf(`services "bar" and "foo" are both configured to listen on port %d`, port))
+	_, _, err = newGen(map[string]uint16{"foo": 0}).ProcPerService(proxy)
+	c.Assert(err, qt.ErrorMatches, `invalid port for service "foo": must not be zero`)
+}
+
+func TestSvcProxyTLS(t *testing.T) {
+	c := qt.New(t)
+
+	proxy := newTestProxy(c)
+
+	gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "foo"}, {Name: "bar"}}})
+	gen.Proxy = ProxyOptions{TLSServices: []string{"foo"}}
+	services, _, err := gen.ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+	gen.ReleaseAddresses()
+
+	sd := services["bar"].Runtime.MustGet().Deployment.ServiceDiscovery.Services
+	c.Assert(sd["foo"].BaseUrl, qt.Matches, `https://127\.0\.0\.1:\d+/service/foo`)
+	c.Assert(sd["bar"].BaseUrl, qt.Matches, `http://127\.0\.0\.1:\d+/service/bar`)
+	c.Assert(sd["foo"].AuthMethods, qt.HasLen, 1)
+	c.Assert(sd["foo"].AuthMethods[0].GetEncoreAuth(), qt.IsNotNil)
+
+	// Requests to the https url are forwarded to the service.
+	ln, err := net.Listen("tcp", services["foo"].ListenAddr.String())
+	c.Assert(err, qt.IsNil)
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = io.WriteString(w, "hello from "+req.URL.Path)
+	})}
+	go srv.Serve(ln)
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	c.Assert(roots.AppendCertsFromPEM([]byte(proxy.TLSCert())), qt.IsTrue)
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}}
+	resp, err := client.Get(sd["foo"].BaseUrl + "/__encore/healthz")
+	c.Assert(err, qt.IsNil)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(body), qt.Equals, "hello from /__encore/healthz")
+
+	gen = newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "foo"}}})
+	gen.Proxy = ProxyOptions{TLSServices: []string{"baz"}}
+	_, err = gen.AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `proxy tls configured for unknown service "baz"`)
+}
@@ -0,0 +1,286 @@
+package run
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"encore.dev/appruntime/exported/config"
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/fns"
+	"encr.dev/pkg/option"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	runtimev1 "encr.dev/proto/encore/runtime/v1"
+)
+
+func TestPubSubDrainWindow(t *testing.T) {
+	c := qt.New(t)
+
+	f, err := appfile.Parse([]byte(`{"graceful_shutdown": {"total": "30s"}}`))
+	c.Assert(err, qt.IsNil)
+	newGen := func(drain option.Option[time.Duration]) *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "foo"}}})
+		gen.app = testApp{appFile: f}
+		gen.PubSubDrainWindow = drain
+		return gen
+	}
+
+	proc, err := newGen(option.Some(20 * time.Second)).AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	shutdown := proc.Runtime.MustGet().Deployment.GracefulShutdown
+	c.Assert(shutdown.PubsubDrain.AsDuration(), qt.Equals, 20*time.Second)
+	c.Assert(shutdown.Total.AsDuration(), qt.Equals, 30*time.Second)
+
+	// The drain window may take up the whole shutdown.
+	proc, err = newGen(option.Some(30 * time.Second)).AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	c.Assert(proc.Runtime.MustGet().Deployment.GracefulShutdown.PubsubDrain.AsDuration(), qt.Equals, 30*time.Second)
+
+	// Without a drain window subscriptions aren't drained.
+	proc, err = newGen(option.None[time.Duration]()).AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	c.Assert(proc.Runtime.MustGet().Deployment.GracefulShutdown.PubsubDrain, qt.IsNil)
+
+	_, err = newGen(option.Some(31 * time.Second)).AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `invalid pubsub drain window 31s: must be positive and at most the total shutdown time of 30s`)
+	_, err = newGen(option.Some(-time.Second)).AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `invalid pubsub drain window -1s: .*`)
+}
+
+func TestPushSubscriptions(t *testing.T) {
+	c := qt.New(t)
+
+	gen := newTestGenerator(&meta.Data{
+		Svcs: []*meta.Service{{Name: "foo"}},
+		PubsubTopics: []*meta.PubSubTopic{{
+			Name:              "events",
+			DeliveryGuarantee: meta.PubSubTopic_AT_LEAST_ONCE,
+			Publishers:        []*meta.PubSubTopic_Publisher{{ServiceName: "foo"}},
+			Subscriptions: []*meta.PubSubTopic_Subscription{
+				{Name: "pull-sub", ServiceName: "foo"},
+				{Name: "push-sub", ServiceName: "foo"},
+			},
+		}},
+	})
+	gen.infraManager = testInfraManager{subConfigs: map[string]config.PubsubSubscription{
+		"push-sub": {
+			PushOnly: true,
+			GCP: &config.PubsubSubscriptionGCPData{
+				ProjectID:       "my-project",
+				PushEndpoint:    "https://example.com/push",
+				PushJWTAudience: "my-audience",
+			},
+		},
+	}}
+	proc, err := gen.AllInOneProc()
+	c.Assert(err, qt.IsNil)
+
+	// Both subscriptions live in the topic's cluster.
+	clusters := proc.Runtime.MustGet().Infra.Resources.PubsubClusters
+	c.Assert(clusters, qt.HasLen, 1)
+	c.Assert(clusters[0].GetNsq(), qt.IsNotNil)
+	c.Assert(clusters[0].Topics, qt.HasLen, 1)
+	c.Assert(clusters[0].Subscriptions, qt.HasLen, 2)
+
+	pull := clusters[0].Subscriptions[0]
+	c.Assert(pull.SubscriptionEncoreName, qt.Equals, "pull-sub")
+	c.Assert(pull.PushOnly, qt.IsFalse)
+	c.Assert(pull.ProviderConfig, qt.IsNil)
+
+	push := clusters[0].Subscriptions[1]
+	c.Assert(push.SubscriptionEncoreName, qt.Equals, "push-sub")
+	c.Assert(push.TopicEncoreName, qt.Equals, "events")
+	c.Assert(push.PushOnly, qt.IsTrue)
+	c.Assert(push.GetGcpConfig().GetProjectId(), qt.Equals, "my-project")
+	c.Assert(push.GetGcpConfig().GetPushEndpoint(), qt.Equals, "https://example.com/push")
+	c.Assert(push.GetGcpConfig().GetPushJwtAudience(), qt.Equals, "my-audience")
+}
+
+func TestDeadLetterSubscriptions(t *testing.T) {
+	c := qt.New(t)
+
+	newGen := func(dlTopic string) *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{
+			Svcs: []*meta.Service{{Name: "foo"}},
+			PubsubTopics: []*meta.PubSubTopic{
+				{
+					Name:              "events",
+					DeliveryGuarantee: meta.PubSubTopic_AT_LEAST_ONCE,
+					Subscriptions:     []*meta.PubSubTopic_Subscription{{Name: "sub", ServiceName: "foo"}},
+				},
+				{Name: "events-dlq", DeliveryGuarantee: meta.PubSubTopic_AT_LEAST_ONCE},
+			},
+		})
+		gen.infraManager = testInfraManager{subConfigs: map[string]config.PubsubSubscription{
+			"sub": {DeadLetter: &config.PubsubDeadLetterPolicy{TopicName: dlTopic, MaxDeliveryAttempts: 5}},
+		}}
+		return gen
+	}
+
+	proc, err := newGen("events-dlq").AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	subs := proc.Runtime.MustGet().Infra.Resources.PubsubClusters[0].Subscriptions
+	c.Assert(subs, qt.HasLen, 1)
+	c.Assert(subs[0].DeadLetter.TopicEncoreName, qt.Equals, "events-dlq")
+	c.Assert(subs[0].DeadLetter.MaxDeliveryAttempts, qt.Equals, int32(5))
+
+	_, err = newGen("missing").AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `subscription "sub" on topic "events": unknown dead-letter topic "missing"`)
+}
+
+func TestPushSubscriptionAckDeadline(t *testing.T) {
+	tests := []struct {
+		name          string
+		ackDeadline   time.Duration
+		retention     time.Duration
+		wantDeadline  time.Duration
+		wantRetention time.Duration
+		wantErr       string
+	}{
+		{name: "defaults", wantDeadline: 10 * time.Second, wantRetention: 7 * 24 * time.Hour},
+		{name: "min", ackDeadline: 10 * time.Second, retention: 10 * time.Minute, wantDeadline: 10 * time.Second, wantRetention: 10 * time.Minute},
+		{name: "max", ackDeadline: 600 * time.Second, retention: 31 * 24 * time.Hour, wantDeadline: 600 * time.Second, wantRetention: 31 * 24 * time.Hour},
+		{name: "deadline_too_short", ackDeadline: 5 * time.Second, wantErr: `.*ack deadline 5s must be between 10s and 10m0s`},
+		{name: "deadline_too_long", ackDeadline: 601 * time.Second, wantErr: `.*ack deadline 10m1s must be between 10s and 10m0s`},
+		{name: "retention_too_short", retention: time.Minute, wantErr: `.*message retention 1m0s must be between 10m0s and 744h0m0s`},
+		{name: "retention_too_long", retention: 32 * 24 * time.Hour, wantErr: `.*message retention 768h0m0s must be between 10m0s and 744h0m0s`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			gen := newTestGenerator(&meta.Data{
+				Svcs: []*meta.Service{{Name: "foo"}},
+				PubsubTopics: []*meta.PubSubTopic{{
+					Name:              "events",
+					DeliveryGuarantee: meta.PubSubTopic_AT_LEAST_ONCE,
+					Subscriptions:     []*meta.PubSubTopic_Subscription{{Name: "sub", ServiceName: "foo"}},
+				}},
+			})
+			gen.infraManager = testInfraManager{subConfigs: map[string]config.PubsubSubscription{
+				"sub": {
+					PushOnly: true,
+					GCP: &config.PubsubSubscriptionGCPData{
+						PushEndpoint:     "https://example.com/push",
+						AckDeadline:      tt.ackDeadline,
+						MessageRetention: tt.retention,
+					},
+				},
+			}}
+			proc, err := gen.AllInOneProc()
+			if tt.wantErr != "" {
+				c.Assert(err, qt.ErrorMatches, tt.wantErr)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			gcpCfg := proc.Runtime.MustGet().Infra.Resources.PubsubClusters[0].Subscriptions[0].GetGcpConfig()
+			c.Assert(gcpCfg.AckDeadline.AsDuration(), qt.Equals, tt.wantDeadline)
+			c.Assert(gcpCfg.MessageRetention.AsDuration(), qt.Equals, tt.wantRetention)
+		})
+	}
+}
+
+func TestKafkaPubSub(t *testing.T) {
+	c := qt.New(t)
+
+	gen := newTestGenerator(&meta.Data{
+		Svcs: []*meta.Service{{Name: "foo"}},
+		PubsubTopics: []*meta.PubSubTopic{
+			{
+				Name:              "orders",
+				DeliveryGuarantee: meta.PubSubTopic_AT_LEAST_ONCE,
+				Publishers:        []*meta.PubSubTopic_Publisher{{ServiceName: "foo"}},
+				Subscriptions:     []*meta.PubSubTopic_Subscription{{Name: "fulfill", ServiceName: "foo"}},
+			},
+		},
+	})
+	gen.infraManager = testInfraManager{pubsub: &config.PubsubProvider{Kafka: &config.KafkaPubsubProvider{
+		Brokers: []string{"kafka-1:9092", "kafka-2:9092"},
+	}}}
+
+	// No runtime can use a Kafka cluster yet.
+	_, err := gen.AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `(?s).*kafka pubsub provider is not supported by the runtime yet`)
+}
+
+func TestPubSubTopicProviders(t *testing.T) {
+	c := qt.New(t)
+
+	gen := newTestGenerator(&meta.Data{
+		Svcs: []*meta.Service{{Name: "foo"}},
+		PubsubTopics: []*meta.PubSubTopic{
+			{
+				Name:              "clicks",
+				DeliveryGuarantee: meta.PubSubTopic_AT_LEAST_ONCE,
+				Publishers:        []*meta.PubSubTopic_Publisher{{ServiceName: "foo"}},
+				Subscriptions:     []*meta.PubSubTopic_Subscription{{Name: "count", ServiceName: "foo"}},
+			},
+			{
+				Name:              "signups",
+				DeliveryGuarantee: meta.PubSubTopic_AT_LEAST_ONCE,
+				Publishers:        []*meta.PubSubTopic_Publisher{{ServiceName: "foo"}},
+				Subscriptions:     []*meta.PubSubTopic_Subscription{{Name: "welcome", ServiceName: "foo"}},
+			},
+		},
+	})
+	gen.PubSubTopicProviders = map[string]config.PubsubProvider{
+		"clicks": {NSQ: &config.NSQProvider{Host: "clicks:4150"}},
+	}
+	proc, err := gen.AllInOneProc()
+	c.Assert(err, qt.IsNil)
+
+	clusters := proc.Runtime.MustGet().Infra.Resources.PubsubClusters
+	c.Assert(clusters, qt.HasLen, 2)
+
+	c.Assert(clusters[0].GetNsq().GetHosts(), qt.DeepEquals, []string{"clicks:4150"})
+	c.Assert(fns.Map(clusters[0].Topics, (*runtimev1.PubSubTopic).GetEncoreName), qt.DeepEquals, []string{"clicks"})
+	c.Assert(fns.Map(clusters[0].Subscriptions, (*runtimev1.PubSubSubscription).GetSubscriptionEncoreName), qt.DeepEquals, []string{"count"})
+
+	c.Assert(clusters[1].GetNsq().GetHosts(), qt.DeepEquals, []string{"localhost:4150"})
+	c.Assert(fns.Map(clusters[1].Topics, (*runtimev1.PubSubTopic).GetEncoreName), qt.DeepEquals, []string{"signups"})
+	c.Assert(fns.Map(clusters[1].Subscriptions, (*runtimev1.PubSubSubscription).GetSubscriptionEncoreName), qt.DeepEquals, []string{"welcome"})
+}
+
+func TestNSQNameTemplate(t *testing.T) {
+	c := qt.New(t)
+
+	newGen := func(template string) *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{
+			Svcs: []*meta.Service{{Name: "foo"}},
+			PubsubTopics: []*meta.PubSubTopic{{
+				Name:              "orders",
+				DeliveryGuarantee: meta.PubSubTopic_AT_LEAST_ONCE,
+				Publishers:        []*meta.PubSubTopic_Publisher{{ServiceName: "foo"}},
+				Subscriptions:     []*meta.PubSubTopic_Subscription{{Name: "fulfill", ServiceName: "foo"}},
+			}},
+		})
+		gen.infraManager = testInfraManager{pubsub: &config.PubsubProvider{NSQ: &config.NSQProvider{
+			Host:         "localhost:4150",
+			NameTemplate: template,
+		}}}
+		gen.EnvName = option.Some("staging")
+		return gen
+	}
+
+	cloudNames := func(gen *RuntimeConfigGenerator) (topic, sub string) {
+		proc, err := gen.AllInOneProc()
+		c.Assert(err, qt.IsNil)
+		clusters := proc.Runtime.MustGet().Infra.Resources.PubsubClusters
+		c.Assert(clusters, qt.HasLen, 1)
+		c.Assert(clusters[0].Topics[0].EncoreName, qt.Equals, "orders")
+		c.Assert(clusters[0].Subscriptions[0].SubscriptionEncoreName, qt.Equals, "fulfill")
+		c.Assert(clusters[0].Subscriptions[0].TopicCloudName, qt.Equals, clusters[0].Topics[0].CloudName)
+		return clusters[0].Topics[0].CloudName, clusters[0].Subscriptions[0].SubscriptionCloudName
+	}
+
+	topic, sub := cloudNames(newGen(""))
+	c.Assert(topic, qt.Equals, "orders")
+	c.Assert(sub, qt.Equals, "fulfill")
+
+	topic, sub = cloudNames(newGen("{env}.{name}"))
+	c.Assert(topic, qt.Equals, "staging.orders")
+	c.Assert(sub, qt.Equals, "staging.fulfill")
+
+	_, err := newGen("{env}-topic").AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `(?s).*nsq pubsub provider: name template "{env}-topic" must contain {name}`)
+}
@@ -0,0 +1,194 @@
+package run
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"encore.dev/appruntime/exported/config"
+	"encr.dev/pkg/option"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestBucketKMSKeys(t *testing.T) {
+	c := qt.New(t)
+
+	const key = "projects/my-project/locations/europe-west1/keyRings/app/cryptoKeys/uploads"
+	newGen := func(keys map[string]string) *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{
+			Svcs: []*meta.Service{{
+				Name:    "foo",
+				Buckets: []*meta.BucketUsage{{Bucket: "uploads"}, {Bucket: "avatars"}},
+			}},
+			Buckets: []*meta.Bucket{{Name: "uploads"}, {Name: "avatars"}},
+		})
+		gen.BucketKMSKeys = keys
+		return gen
+	}
+
+	proc, err := newGen(map[string]string{"uploads": key}).AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	kmsKeys := make(map[string]*string)
+	for _, bkt := range proc.Runtime.MustGet().Infra.Resources.BucketClusters[0].Buckets {
+		kmsKeys[bkt.EncoreName] = bkt.KmsKey
+	}
+	c.Assert(kmsKeys["uploads"], qt.IsNotNil)
+	c.Assert(*kmsKeys["uploads"], qt.Equals, key)
+	c.Assert(kmsKeys["avatars"], qt.IsNil)
+
+	// All malformed keys are reported.
+	_, err = newGen(map[string]string{
+		"uploads": "projects/my-project/keyRings/app/cryptoKeys/uploads",
+		"avatars": key + "/cryptoKeyVersions/1",
+		"unknown": key,
+	}).AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `(?s)invalid kms key "projects/my-project/locations/europe-west1/keyRings/app/cryptoKeys/uploads/cryptoKeyVersions/1" for bucket "avatars": must be of the form projects/\*/locations/\*/keyRings/\*/cryptoKeys/\*`+
+		`\nkms key configured for unknown bucket "unknown"`+
+		`\ninvalid kms key "projects/my-project/keyRings/app/cryptoKeys/uploads" for bucket "uploads": .*`)
+}
+
+func TestProcPerService_BucketAccess(t *testing.T) {
+	c := qt.New(t)
+
+	proxy := newTestProxy(c)
+
+	gen := newTestGenerator(&meta.Data{
+		Svcs: []*meta.Service{
+			{Name: "foo", Buckets: []*meta.BucketUsage{{Bucket: "uploads"}}},
+			{Name: "bar"},
+			{Name: "auth", Buckets: []*meta.BucketUsage{{Bucket: "avatars"}}},
+		},
+		Buckets: []*meta.Bucket{{Name: "uploads"}, {Name: "avatars"}},
+		Gateways: []*meta.Gateway{{
+			EncoreName: "api-gateway",
+			Explicit:   &meta.Gateway_Explicit{ServiceName: "auth"},
+		}},
+	})
+	services, gateways, err := gen.ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+
+	bucketNames := func(p *ProcConfig) []string {
+		var names []string
+		for _, cluster := range p.Runtime.MustGet().Infra.Resources.BucketClusters {
+			for _, bkt := range cluster.Buckets {
+				names = append(names, bkt.EncoreName)
+			}
+		}
+		return names
+	}
+	c.Assert(bucketNames(services["foo"]), qt.DeepEquals, []string{"uploads"})
+	c.Assert(bucketNames(services["bar"]), qt.HasLen, 0)
+	c.Assert(bucketNames(gateways["api-gateway"]), qt.DeepEquals, []string{"avatars"})
+}
+
+func TestBucketSignedURLTTL(t *testing.T) {
+	c := qt.New(t)
+
+	md := &meta.Data{Buckets: []*meta.Bucket{{Name: "uploads"}}}
+	ttl := 15 * time.Minute
+	gen := newTestGenerator(md)
+	gen.infraManager = testInfraManager{signedURLTTL: &ttl}
+	proc, err := gen.AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	clusters := proc.Runtime.MustGet().Infra.Resources.BucketClusters
+	c.Assert(clusters, qt.HasLen, 1)
+	c.Assert(clusters[0].DefaultSignedUrlTtl.AsDuration(), qt.Equals, ttl)
+
+	// Unset keeps the runtime default.
+	gen = newTestGenerator(md)
+	proc, err = gen.AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	c.Assert(proc.Runtime.MustGet().Infra.Resources.BucketClusters[0].DefaultSignedUrlTtl, qt.IsNil)
+}
+
+func TestBucketPublicURLs(t *testing.T) {
+	c := qt.New(t)
+
+	gen := newTestGenerator(&meta.Data{
+		Svcs: []*meta.Service{{Name: "foo", Buckets: []*meta.BucketUsage{{Bucket: "assets"}, {Bucket: "images"}}}},
+		Buckets: []*meta.Bucket{
+			{Name: "assets", Public: true},
+			{Name: "images", Public: true},
+		},
+	})
+	gen.BucketPublicURLs = map[string]string{"assets": "https://cdn.example.com"}
+	proc, err := gen.AllInOneProc()
+	c.Assert(err, qt.IsNil)
+
+	urls := make(map[string]string)
+	for _, bkt := range proc.Runtime.MustGet().Infra.Resources.BucketClusters[0].Buckets {
+		urls[bkt.EncoreName] = bkt.GetPublicBaseUrl()
+	}
+	c.Assert(urls, qt.DeepEquals, map[string]string{
+		"assets": "https://cdn.example.com",
+		"images": "http://localhost:4443/images",
+	})
+}
+
+func TestRedisKeyPrefix(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace option.Option[string]
+		prefix    string
+		want      string
+	}{
+		{name: "no_namespace", namespace: option.None[string](), prefix: "cache/", want: "cache/"},
+		{name: "namespace", namespace: option.Some("staging"), prefix: "cache/", want: "staging/cache/"},
+		{name: "no_prefix", namespace: option.Some("staging"), prefix: "", want: "staging/"},
+		{name: "already_namespaced", namespace: option.Some("staging"), prefix: "staging/cache/", want: "staging/cache/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			gen := &RuntimeConfigGenerator{RedisKeyNamespace: tt.namespace}
+			c.Assert(gen.redisKeyPrefix(tt.prefix), qt.Equals, tt.want)
+		})
+	}
+
+	c := qt.New(t)
+	gen := newTestGenerator(&meta.Data{
+		Svcs:          []*meta.Service{{Name: "foo"}},
+		CacheClusters: []*meta.CacheCluster{{Name: "cache", Keyspaces: []*meta.CacheCluster_Keyspace{{Service: "foo"}}}},
+	})
+	gen.RedisKeyNamespace = option.Some("staging")
+	proc, err := gen.AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	db := proc.Runtime.MustGet().Infra.Resources.RedisClusters[0].Databases[0]
+	c.Assert(db.GetKeyPrefix(), qt.Equals, "staging/")
+}
+
+func TestRedisClientCert(t *testing.T) {
+	c := qt.New(t)
+
+	certPEM, keyPEM := generateTestCert(c)
+	caCert := "-----BEGIN CERTIFICATE-----\nca\n-----END CERTIFICATE-----"
+	gen := newTestGenerator(&meta.Data{
+		Svcs:          []*meta.Service{{Name: "foo"}},
+		CacheClusters: []*meta.CacheCluster{{Name: "cache", Keyspaces: []*meta.CacheCluster_Keyspace{{Service: "foo"}}}},
+	})
+	gen.infraManager = testInfraManager{redisServer: &config.RedisServer{
+		Host:         "redis.example.com:6380",
+		ServerCACert: caCert,
+		ClientCert:   certPEM,
+		ClientKey:    keyPEM,
+	}}
+	proc, err := gen.AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	infra := proc.Runtime.MustGet().Infra
+
+	c.Assert(infra.Credentials.ClientCerts, qt.HasLen, 1)
+	cert := infra.Credentials.ClientCerts[0]
+	c.Assert(cert.Cert, qt.Equals, certPEM)
+	c.Assert(string(cert.Key.GetEmbedded()), qt.Equals, keyPEM)
+
+	c.Assert(infra.Credentials.RedisRoles, qt.HasLen, 1)
+	c.Assert(infra.Credentials.RedisRoles[0].GetClientCertRid(), qt.Equals, cert.Rid)
+	c.Assert(infra.Resources.RedisClusters[0].Servers[0].TlsConfig.GetServerCaCert(), qt.Equals, caCert)
+
+	// A mismatched key is rejected.
+	gen = newTestGenerator(gen.md)
+	gen.infraManager = testInfraManager{redisServer: &config.RedisServer{Host: "redis:6379", ClientCert: certPEM}}
+	_, err = gen.AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `invalid client certificate for redis cluster "cache": .*`)
+}
@@ -0,0 +1,1003 @@
+package run
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/klauspost/compress/zstd"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/proto"
+
+	"encore.dev/appruntime/exported/config"
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/option"
+	"encr.dev/pkg/svcproxy"
+	"encr.dev/pkg/vcs"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	runtimev1 "encr.dev/proto/encore/runtime/v1"
+)
+
+func TestEncodeEnvData(t *testing.T) {
+	c := qt.New(t)
+
+	// Simulate a large app with hundreds of endpoints.
+	md := &meta.Data{}
+	for i := range 20 {
+		svc := &meta.Service{Name: fmt.Sprintf("service%d", i)}
+		for j := range 25 {
+			svc.Rpcs = append(svc.Rpcs, &meta.RPC{
+				Name:        fmt.Sprintf("Endpoint%d", j),
+				ServiceName: svc.Name,
+				AccessType:  meta.RPC_PUBLIC,
+				HttpMethods: []string{"GET", "POST"},
+			})
+		}
+		md.Svcs = append(md.Svcs, svc)
+	}
+	data, err := proto.Marshal(md)
+	c.Assert(err, qt.IsNil)
+
+	gzipEnc, err := encodeEnvData(EnvCodecGzip, gzip.DefaultCompression, data)
+	c.Assert(err, qt.IsNil)
+	zstdEnc, err := encodeEnvData(EnvCodecZstd, gzip.DefaultCompression, data)
+	c.Assert(err, qt.IsNil)
+	c.Assert(strings.HasPrefix(gzipEnc, "gzip:"), qt.IsTrue)
+	c.Assert(strings.HasPrefix(zstdEnc, "zstd:"), qt.IsTrue)
+
+	c.Logf("raw: %d bytes, gzip: %d bytes, zstd: %d bytes", base64.StdEncoding.EncodedLen(len(data)), len(gzipEnc), len(zstdEnc))
+	c.Assert(len(gzipEnc) < base64.StdEncoding.EncodedLen(len(data)), qt.IsTrue)
+	c.Assert(len(zstdEnc) < len(gzipEnc), qt.IsTrue)
+
+	for _, enc := range []string{gzipEnc, zstdEnc} {
+		got := new(meta.Data)
+		c.Assert(proto.Unmarshal(decodeEnvData(c, enc), got), qt.IsNil)
+		c.Assert(proto.Equal(got, md), qt.IsTrue)
+	}
+}
+
+func TestProcEnvs_GzipLevel(t *testing.T) {
+	c := qt.New(t)
+
+	md := &meta.Data{Svcs: []*meta.Service{{Name: "foo"}}}
+	for i := range 200 {
+		md.Svcs[0].Rpcs = append(md.Svcs[0].Rpcs, &meta.RPC{
+			Name:        fmt.Sprintf("Endpoint%d", i),
+			ServiceName: "foo",
+			Doc:         ptrOrNil(strings.Repeat(fmt.Sprintf("endpoint %d does things. ", i), 5)),
+		})
+	}
+	metaEnv := func(level option.Option[int]) string {
+		gen := newTestGenerator(md)
+		gen.IncludeMeta = true
+		gen.GzipLevel = level
+		proc, err := gen.AllInOneProc()
+		c.Assert(err, qt.IsNil)
+		envs, err := gen.ProcEnvs(proc)
+		c.Assert(err, qt.IsNil)
+		for _, env := range envs {
+			if val, ok := strings.CutPrefix(env, metaEnvVar+"="); ok {
+				return val
+			}
+		}
+		c.Fatalf("no metadata env var in %v", envs)
+		return ""
+	}
+
+	none := metaEnv(option.Some(gzip.NoCompression))
+	best := metaEnv(option.Some(gzip.BestCompression))
+	c.Assert(len(best) < len(none), qt.IsTrue, qt.Commentf("best: %d bytes, none: %d bytes", len(best), len(none)))
+
+	// Both levels decode to the same metadata.
+	for _, enc := range []string{none, best} {
+		got := new(meta.Data)
+		c.Assert(proto.Unmarshal(decodeEnvData(c, enc), got), qt.IsNil)
+		c.Assert(proto.Equal(got, md), qt.IsTrue)
+	}
+
+	gen := newTestGenerator(md)
+	gen.GzipLevel = option.Some(10)
+	_, err := gen.AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `invalid gzip level 10: must be between -2 and 9`)
+
+	_, err = encodeEnvData(EnvCodecGzip, 42, []byte("data"))
+	c.Assert(err, qt.IsNotNil)
+}
+
+func TestProcEnvs_IncludeMeta(t *testing.T) {
+	c := qt.New(t)
+
+	proxy := newTestProxy(c)
+
+	md := &meta.Data{
+		Svcs: []*meta.Service{{Name: "foo"}, {Name: "bar"}},
+		Pkgs: []*meta.Package{
+			{RelPath: "foo", ServiceName: "foo"},
+			{RelPath: "bar", ServiceName: "bar"},
+		},
+	}
+	gen := newTestGenerator(md)
+	gen.IncludeMeta = true
+	gen.RuntimeConfigFormat = RuntimeConfigV2
+	services, _, err := gen.ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+	gen.ReleaseAddresses()
+
+	procMeta := func(proc *ProcConfig) *meta.Data {
+		envs, err := gen.ProcEnvs(proc)
+		c.Assert(err, qt.IsNil)
+		for _, env := range envs {
+			if val, ok := strings.CutPrefix(env, metaEnvVar+"="); ok {
+				procMd := new(meta.Data)
+				c.Assert(proto.Unmarshal(decodeEnvData(c, val), procMd), qt.IsNil)
+				return procMd
+			}
+		}
+		return nil
+	}
+
+	// By default every proc gets the full metadata.
+	c.Assert(proto.Equal(procMeta(services["foo"]), md), qt.IsTrue)
+
+	// Procs that don't need the metadata can omit it.
+	services["bar"].IncludeMeta = option.Some(false)
+	c.Assert(procMeta(services["bar"]), qt.IsNil)
+
+	// Procs can also request it when the generator doesn't include it.
+	gen.IncludeMeta = false
+	services["foo"].IncludeMeta = option.Some(true)
+	c.Assert(proto.Equal(procMeta(services["foo"]), md), qt.IsTrue)
+}
+
+func TestProcEnvs_MaxEnvSize(t *testing.T) {
+	c := qt.New(t)
+
+	// Generate metadata with a large, incompressible doc string.
+	doc := make([]byte, 64*1024)
+	_, err := rand.Read(doc)
+	c.Assert(err, qt.IsNil)
+	md := &meta.Data{
+		Svcs: []*meta.Service{{Name: "svc"}},
+		Pkgs: []*meta.Package{{RelPath: "svc", ServiceName: "svc", Doc: base64.StdEncoding.EncodeToString(doc)}},
+	}
+
+	gen := newTestGenerator(md)
+	gen.IncludeMeta = true
+	gen.RuntimeConfigFormat = RuntimeConfigV2
+	proc, err := gen.AllInOneProc()
+	c.Assert(err, qt.IsNil)
+
+	// The metadata fits within the default limit.
+	_, err = gen.ProcEnvs(proc)
+	c.Assert(err, qt.IsNil)
+
+	gen.MaxEnvSize = 32 * 1024
+	_, err = gen.ProcEnvs(proc)
+	c.Assert(err, qt.ErrorMatches, `environment variable ENCORE_APP_META is \d+ bytes, exceeding the maximum of 32768 bytes.*`)
+}
+
+func TestProcEnvs_MetaFileFallback(t *testing.T) {
+	c := qt.New(t)
+
+	md := &meta.Data{Svcs: []*meta.Service{{Name: "svc"}}}
+	gen := newTestGenerator(md)
+	gen.IncludeMeta = true
+	gen.MaxMetaEnvSize = 1
+	gen.RuntimeConfigFormat = RuntimeConfigV2
+	proc, err := gen.AllInOneProc()
+	c.Assert(err, qt.IsNil)
+
+	envs, err := gen.ProcEnvs(proc)
+	c.Assert(err, qt.IsNil)
+	c.Assert(gen.TempFiles(), qt.HasLen, 1)
+	defer func() { _ = os.Remove(gen.TempFiles()[0]) }()
+	c.Assert(envs, qt.Contains, metaPathEnvVar+"="+gen.TempFiles()[0])
+
+	data, err := os.ReadFile(gen.TempFiles()[0])
+	c.Assert(err, qt.IsNil)
+	got := new(meta.Data)
+	c.Assert(proto.Unmarshal(data, got), qt.IsNil)
+	c.Assert(proto.Equal(got, md), qt.IsTrue)
+
+	// Subsequent procs reuse the same file.
+	_, err = gen.ProcEnvs(proc)
+	c.Assert(err, qt.IsNil)
+	c.Assert(gen.TempFiles(), qt.HasLen, 1)
+}
+
+func TestProcEnvs_RuntimeConfigInFile(t *testing.T) {
+	c := qt.New(t)
+
+	gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "svc"}}})
+	gen.RuntimeConfigInFile = true
+	gen.RuntimeConfigFormat = RuntimeConfigV2
+	proc, err := gen.AllInOneProc()
+	c.Assert(err, qt.IsNil)
+
+	envs, err := gen.ProcEnvs(proc)
+	c.Assert(err, qt.IsNil)
+	c.Assert(gen.TempFiles(), qt.HasLen, 1)
+	path := gen.TempFiles()[0]
+	defer func() { _ = os.Remove(path) }()
+
+	c.Assert(envs, qt.Contains, runtimeCfgPathEnvVar+"="+path)
+	for _, env := range envs {
+		c.Assert(strings.HasPrefix(env, runtimeCfgEnvVar+"="), qt.IsFalse)
+	}
+
+	data, err := os.ReadFile(path)
+	c.Assert(err, qt.IsNil)
+	got := new(runtimev1.RuntimeConfig)
+	c.Assert(proto.Unmarshal(data, got), qt.IsNil)
+	c.Assert(proto.Equal(got, proc.Runtime.MustGet()), qt.IsTrue)
+}
+
+func TestGracefulShutdownConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		appFile string
+		want    [3]time.Duration // total, shutdown hooks, handlers
+		wantErr string
+	}{
+		{
+			name:    "defaults",
+			appFile: `{}`,
+			want:    [3]time.Duration{10 * time.Second, 4 * time.Second, 2 * time.Second},
+		},
+		{
+			name:    "custom",
+			appFile: `{"graceful_shutdown": {"total": "1m", "shutdown_hooks": "30s", "handlers": "20s"}}`,
+			want:    [3]time.Duration{time.Minute, 30 * time.Second, 20 * time.Second},
+		},
+		{
+			name:    "partial",
+			appFile: `{"graceful_shutdown": {"total": "30s"}}`,
+			want:    [3]time.Duration{30 * time.Second, 4 * time.Second, 2 * time.Second},
+		},
+		{
+			name:    "hooks_exceed_total",
+			appFile: `{"graceful_shutdown": {"total": "3s"}}`,
+			wantErr: `.*shutdown_hooks \(4s\) must not exceed total \(3s\)`,
+		},
+		{
+			name:    "handlers_exceed_hooks",
+			appFile: `{"graceful_shutdown": {"shutdown_hooks": "5s", "handlers": "6s"}}`,
+			wantErr: `.*handlers \(6s\) must not exceed shutdown_hooks \(5s\)`,
+		},
+		{
+			name:    "negative",
+			appFile: `{"graceful_shutdown": {"handlers": "-1s"}}`,
+			wantErr: `.*must not be negative.*`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			f, err := appfile.Parse([]byte(tt.appFile))
+			c.Assert(err, qt.IsNil)
+
+			got, err := gracefulShutdownConfig(f.GracefulShutdown)
+			if tt.wantErr != "" {
+				c.Assert(err, qt.ErrorMatches, tt.wantErr)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Assert([3]time.Duration{
+				got.Total.AsDuration(),
+				got.ShutdownHooks.AsDuration(),
+				got.Handlers.AsDuration(),
+			}, qt.Equals, tt.want)
+		})
+	}
+}
+
+func TestWorkerThreads(t *testing.T) {
+	c := qt.New(t)
+
+	md := &meta.Data{
+		Svcs: []*meta.Service{{Name: "foo"}, {Name: "bar"}},
+	}
+	f, err := appfile.Parse([]byte(`{"build": {"worker_pooling": true, "worker_threads": 4, "service_worker_threads": {"bar": 0}}}`))
+	c.Assert(err, qt.IsNil)
+
+	gen := newTestGenerator(md)
+	gen.app = testApp{appFile: f}
+	proc, err := gen.AllInOneProc()
+	c.Assert(err, qt.IsNil)
+
+	threads := make(map[string]int32)
+	for _, svc := range proc.Runtime.MustGet().Deployment.HostedServices {
+		c.Assert(svc.WorkerThreads, qt.IsNotNil)
+		threads[svc.Name] = *svc.WorkerThreads
+	}
+	c.Assert(threads, qt.DeepEquals, map[string]int32{"foo": 4, "bar": 0})
+
+	f.Build.ServiceWorkerThreads = map[string]int{"unknown": 2}
+	gen = newTestGenerator(md)
+	gen.app = testApp{appFile: f}
+	_, err = gen.AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `.*worker threads configured for unknown service "unknown"`)
+
+	// All invalid settings are reported, in service order.
+	f.Build.WorkerThreads = -1
+	f.Build.ServiceWorkerThreads = map[string]int{"foo": -2, "unknown": 2, "bar": -3}
+	gen = newTestGenerator(md)
+	gen.app = testApp{appFile: f}
+	_, err = gen.AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `(?s).*invalid worker_threads -1: must be non-negative\n`+
+		`invalid worker threads -3 for service "bar": must be non-negative\n`+
+		`invalid worker threads -2 for service "foo": must be non-negative\n`+
+		`worker threads configured for unknown service "unknown".*`)
+}
+
+func TestValidateBuildSettings(t *testing.T) {
+	md := &meta.Data{Svcs: []*meta.Service{{Name: "foo"}}}
+	tests := []struct {
+		name    string
+		appFile string
+		wantErr string
+	}{
+		{
+			name:    "valid",
+			appFile: `{"log_level": "debug", "log_format": "json", "build": {"worker_pooling": true, "worker_threads": 2}}`,
+		},
+		{
+			name:    "unknown_log_level",
+			appFile: `{"log_level": "verbose"}`,
+			wantErr: `invalid log_level "verbose": must be one of trace, debug, info, warn, error`,
+		},
+		{
+			name:    "unknown_log_format",
+			appFile: `{"log_format": "xml"}`,
+			wantErr: `invalid log_format: unknown log format "xml"`,
+		},
+		{
+			name:    "service_log_level",
+			appFile: `{"service_logs": {"foo": {"level": "WARN"}}}`,
+			wantErr: `invalid log level for service "foo" "WARN": must be one of .*`,
+		},
+		{
+			name:    "threads_without_pooling",
+			appFile: `{"build": {"worker_threads": 4}}`,
+			wantErr: `worker_threads and service_worker_threads require worker_pooling to be enabled`,
+		},
+		{
+			name:    "negative_threads",
+			appFile: `{"build": {"worker_pooling": true, "worker_threads": -1}}`,
+			wantErr: `invalid worker_threads -1: must be non-negative`,
+		},
+		{
+			name:    "all_errors_reported",
+			appFile: `{"log_level": "loud", "service_logs": {"bar": {}}, "build": {"worker_threads": 4}}`,
+			wantErr: `(?s)invalid log_level "loud".*log config configured for unknown service "bar".*require worker_pooling to be enabled`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			f, err := appfile.Parse([]byte(tt.appFile))
+			c.Assert(err, qt.IsNil)
+
+			gen := newTestGenerator(md)
+			gen.app = testApp{appFile: f}
+			err = gen.ValidateBuildSettings()
+			if tt.wantErr == "" {
+				c.Assert(err, qt.IsNil)
+				return
+			}
+			c.Assert(err, qt.ErrorMatches, tt.wantErr)
+
+			// Invalid settings fail generation before the config is built.
+			_, err = gen.AllInOneProc()
+			c.Assert(err, qt.ErrorMatches, tt.wantErr)
+		})
+	}
+
+	c := qt.New(t)
+	gen := newTestGenerator(md)
+	gen.LogLevel = option.Some("loud")
+	c.Assert(gen.ValidateBuildSettings(), qt.ErrorMatches, `invalid log level override "loud": .*`)
+}
+
+func TestAllInOneProcWithRuntimeConfig(t *testing.T) {
+	c := qt.New(t)
+
+	gen := newTestGenerator(&meta.Data{
+		Svcs: []*meta.Service{{Name: "foo"}, {Name: "bar"}},
+	})
+	proc, conf, err := gen.AllInOneProcWithRuntimeConfig()
+	c.Assert(err, qt.IsNil)
+	c.Assert(conf, qt.IsNotNil)
+	c.Assert(proc.Runtime.MustGet(), qt.Equals, conf)
+	c.Assert(conf.Deployment.HostedServices, qt.HasLen, 2)
+}
+
+func TestStableRIDs(t *testing.T) {
+	c := qt.New(t)
+
+	md := &meta.Data{
+		Svcs:         []*meta.Service{{Name: "foo", Databases: []string{"orders"}}},
+		SqlDatabases: []*meta.SQLDatabase{{Name: "orders"}},
+		PubsubTopics: []*meta.PubSubTopic{{Name: "created", Publishers: []*meta.PubSubTopic_Publisher{{ServiceName: "foo"}}}},
+	}
+	gen := func(md *meta.Data, prior map[string]string) (*RuntimeConfigGenerator, *runtimev1.Infrastructure_Resources) {
+		g := newTestGenerator(md)
+		g.PriorRIDs = prior
+		_, conf, err := g.AllInOneProcWithRuntimeConfig()
+		c.Assert(err, qt.IsNil)
+		return g, conf.Infra.Resources
+	}
+
+	first, res1 := gen(md, nil)
+
+	// Regenerate with an additional topic.
+	md.PubsubTopics = append(md.PubsubTopics, &meta.PubSubTopic{Name: "shipped", Publishers: []*meta.PubSubTopic_Publisher{{ServiceName: "foo"}}})
+	_, res2 := gen(md, first.RIDs())
+
+	c.Assert(res2.SqlClusters[0].Rid, qt.Equals, res1.SqlClusters[0].Rid)
+	c.Assert(res2.SqlClusters[0].Databases[0].Rid, qt.Equals, res1.SqlClusters[0].Databases[0].Rid)
+	c.Assert(res2.PubsubClusters[0].Rid, qt.Equals, res1.PubsubClusters[0].Rid)
+	c.Assert(res2.PubsubClusters[0].Topics[0].Rid, qt.Equals, res1.PubsubClusters[0].Topics[0].Rid)
+
+	// Only the new topic gets a new resource id.
+	c.Assert(res2.PubsubClusters[0].Topics, qt.HasLen, 2)
+	c.Assert(res2.PubsubClusters[0].Topics[1].EncoreName, qt.Equals, "shipped")
+	c.Assert(res2.PubsubClusters[0].Topics[1].Rid, qt.Not(qt.Equals), res1.PubsubClusters[0].Topics[0].Rid)
+}
+
+func TestRuntimeConfigFormat(t *testing.T) {
+	c := qt.New(t)
+
+	procEnvs := func(format RuntimeConfigFormat) (string, []string) {
+		gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "svc"}}})
+		gen.AppID = option.Some("app")
+		gen.RuntimeConfigFormat = format
+		proc, err := gen.AllInOneProc()
+		c.Assert(err, qt.IsNil)
+		envs, err := gen.ProcEnvs(proc)
+		c.Assert(err, qt.IsNil)
+		for _, env := range envs {
+			if val, ok := strings.CutPrefix(env, runtimeCfgEnvVar+"="); ok {
+				return val, envs
+			}
+		}
+		c.Fatalf("missing %s in %v", runtimeCfgEnvVar, envs)
+		return "", nil
+	}
+	hasSecretsEnv := func(envs []string) bool {
+		return slices.ContainsFunc(envs, func(env string) bool { return strings.HasPrefix(env, appSecretsEnvVar+"=") })
+	}
+
+	// The legacy format is base64-encoded JSON, with secrets passed separately.
+	val, envs := procEnvs(RuntimeConfigLegacy)
+	data, err := base64.RawURLEncoding.DecodeString(val)
+	c.Assert(err, qt.IsNil)
+	var legacy config.Runtime
+	c.Assert(json.Unmarshal(data, &legacy), qt.IsNil)
+	c.Assert(legacy.AppID, qt.Equals, "app")
+	c.Assert(hasSecretsEnv(envs), qt.IsTrue)
+
+	// The v2 format is the encoded protobuf, which includes the secrets.
+	val, envs = procEnvs(RuntimeConfigV2)
+	var conf runtimev1.RuntimeConfig
+	c.Assert(proto.Unmarshal(decodeEnvData(c, val), &conf), qt.IsNil)
+	c.Assert(conf.Environment.AppId, qt.Equals, "app")
+	c.Assert(hasSecretsEnv(envs), qt.IsFalse)
+}
+
+func TestProcEnvs_ProcRuntimeConfigFormat(t *testing.T) {
+	c := qt.New(t)
+
+	proxy := newTestProxy(c)
+
+	gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "foo"}, {Name: "bar"}}})
+	gen.AppID = option.Some("app")
+	services, _, err := gen.ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+	gen.ReleaseAddresses()
+
+	runtimeCfg := func(proc *ProcConfig) string {
+		envs, err := gen.ProcEnvs(proc)
+		c.Assert(err, qt.IsNil)
+		for _, env := range envs {
+			if val, ok := strings.CutPrefix(env, runtimeCfgEnvVar+"="); ok {
+				return val
+			}
+		}
+		c.Fatalf("missing %s in %v", runtimeCfgEnvVar, envs)
+		return ""
+	}
+
+	// Procs in the same group can use different formats.
+	services["foo"].RuntimeConfigFormat = option.Some(RuntimeConfigV2)
+	var conf runtimev1.RuntimeConfig
+	c.Assert(proto.Unmarshal(decodeEnvData(c, runtimeCfg(services["foo"])), &conf), qt.IsNil)
+	c.Assert(conf.Environment.AppId, qt.Equals, "app")
+
+	data, err := base64.RawURLEncoding.DecodeString(runtimeCfg(services["bar"]))
+	c.Assert(err, qt.IsNil)
+	var legacy config.Runtime
+	c.Assert(json.Unmarshal(data, &legacy), qt.IsNil)
+	c.Assert(legacy.AppID, qt.Equals, "app")
+}
+
+func TestVCSRevision(t *testing.T) {
+	c := qt.New(t)
+
+	gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "foo"}}})
+	gen.VCS = option.Some(vcs.Status{Revision: "0123abcd", Uncommitted: true})
+	proc, err := gen.AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	deployment := proc.Runtime.MustGet().Deployment
+	c.Assert(deployment.GetVcsRevision(), qt.Equals, "0123abcd")
+	c.Assert(deployment.VcsUncommitted, qt.IsTrue)
+}
+
+func TestTransformRuntimeConfig(t *testing.T) {
+	c := qt.New(t)
+
+	gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "foo"}}})
+	gen.TransformRuntimeConfig = func(conf *runtimev1.RuntimeConfig) error {
+		conf.Deployment.VcsUncommitted = !conf.Deployment.VcsUncommitted
+		conf.Environment.Cloud = runtimev1.Environment_CLOUD_GCP
+		return nil
+	}
+	proc, err := gen.AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	conf := proc.Runtime.MustGet()
+	c.Assert(conf.Deployment.VcsUncommitted, qt.IsTrue)
+	c.Assert(conf.Environment.Cloud, qt.Equals, runtimev1.Environment_CLOUD_GCP)
+
+	gen = newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "foo"}}})
+	gen.TransformRuntimeConfig = func(conf *runtimev1.RuntimeConfig) error {
+		return errors.New("unsupported provider")
+	}
+	_, err = gen.AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `.*transform runtime config: unsupported provider`)
+}
+
+func TestDeployedAt(t *testing.T) {
+	c := qt.New(t)
+
+	deployedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "foo"}}})
+	gen.DeployedAt = option.Some(deployedAt)
+	proc, err := gen.AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	c.Assert(proc.Runtime.MustGet().Deployment.DeployedAt.AsTime(), qt.Equals, deployedAt)
+}
+
+func TestProcPerService_ExtraEnv(t *testing.T) {
+	c := qt.New(t)
+
+	proxy := newTestProxy(c)
+
+	newGen := func() *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{
+			Svcs:     []*meta.Service{{Name: "foo"}, {Name: "bar"}},
+			Gateways: []*meta.Gateway{{EncoreName: "api-gateway"}},
+		})
+		gen.Gateways = GatewayOptions{
+			Configs:  map[string]GatewayConfig{"api-gateway": {BaseURL: "http://localhost:4000"}},
+			ExtraEnv: map[string][]string{"api-gateway": {"GW_DEBUG=true"}},
+		}
+		gen.SvcExtraEnv = map[string][]string{"foo": {"FEATURE_X=1"}}
+		return gen
+	}
+
+	services, gateways, err := newGen().ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+	c.Assert(services["foo"].ExtraEnv, qt.Contains, "FEATURE_X=1")
+	c.Assert(services["bar"].ExtraEnv, qt.Not(qt.Contains), "FEATURE_X=1")
+	c.Assert(gateways["api-gateway"].ExtraEnv, qt.DeepEquals, []string{"GW_DEBUG=true"})
+
+	_, services, gateways, err = newGen().ProcPerServiceWithNewRuntimeConfig(proxy)
+	c.Assert(err, qt.IsNil)
+	c.Assert(services["foo"].ExtraEnv, qt.Contains, "FEATURE_X=1")
+	c.Assert(services["bar"].ExtraEnv, qt.Not(qt.Contains), "FEATURE_X=1")
+	c.Assert(gateways["api-gateway"].ExtraEnv, qt.Contains, "GW_DEBUG=true")
+
+	gen := newGen()
+	gen.SvcExtraEnv = map[string][]string{"foo": {"NOVALUE"}}
+	_, _, err = gen.ProcPerService(proxy)
+	c.Assert(err, qt.ErrorMatches, `invalid extra environment variable "NOVALUE" for service "foo": must have the form KEY=value`)
+}
+
+func TestSvcRequestTimeouts(t *testing.T) {
+	c := qt.New(t)
+
+	newGen := func(timeouts map[string]appfile.Duration) *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "foo"}, {Name: "bar"}, {Name: "baz"}}})
+		gen.app = testApp{&appfile.File{ServiceRequestTimeouts: timeouts}}
+		return gen
+	}
+
+	proc, err := newGen(map[string]appfile.Duration{
+		"foo": appfile.Duration(5 * time.Second),
+		"bar": 0,
+	}).AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	svcs := proc.Runtime.MustGet().Deployment.HostedServices
+	c.Assert(svcs, qt.HasLen, 3)
+	for _, svc := range svcs {
+		if svc.Name == "foo" {
+			c.Assert(svc.RequestTimeout.AsDuration(), qt.Equals, 5*time.Second)
+		} else {
+			c.Assert(svc.RequestTimeout, qt.IsNil, qt.Commentf("service %s", svc.Name))
+		}
+	}
+
+	_, err = newGen(map[string]appfile.Duration{
+		"foo": appfile.Duration(-time.Second),
+		"qux": appfile.Duration(time.Second),
+	}).AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `invalid request timeout -1s for service "foo": must not be negative\nrequest timeout configured for unknown service "qux"`)
+}
+
+func TestSvcResourceLimits(t *testing.T) {
+	c := qt.New(t)
+
+	proxy := newTestProxy(c)
+
+	newGen := func(appFile *appfile.File) *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{Svcs: []*meta.Service{{Name: "foo"}, {Name: "bar"}}})
+		gen.app = testApp{appFile}
+		return gen
+	}
+	appFile := &appfile.File{
+		ServiceResources: map[string]appfile.ServiceResources{
+			"foo": {MemoryLimitMB: 512, CPULimit: 0.5},
+		},
+	}
+
+	gen := newGen(appFile)
+	services, _, err := gen.ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+	gen.ReleaseAddresses()
+	c.Assert(services["foo"].MemoryLimit, qt.Equals, int64(512<<20))
+	c.Assert(services["foo"].CPULimit, qt.Equals, 0.5)
+	c.Assert(services["foo"].ExtraEnv, qt.Contains, "GOMEMLIMIT=536870912")
+	c.Assert(services["bar"].MemoryLimit, qt.Equals, int64(0))
+	c.Assert(slices.ContainsFunc(services["bar"].ExtraEnv, func(kv string) bool {
+		return strings.HasPrefix(kv, "GOMEMLIMIT=")
+	}), qt.IsFalse)
+
+	// An explicit GOMEMLIMIT is left alone.
+	gen = newGen(appFile)
+	gen.SvcExtraEnv = map[string][]string{"foo": {"GOMEMLIMIT=256MiB"}}
+	_, services, _, err = gen.ProcPerServiceWithNewRuntimeConfig(proxy)
+	c.Assert(err, qt.IsNil)
+	gen.ReleaseAddresses()
+	c.Assert(services["foo"].MemoryLimit, qt.Equals, int64(512<<20))
+	c.Assert(services["foo"].ExtraEnv, qt.Contains, "GOMEMLIMIT=256MiB")
+	c.Assert(services["foo"].ExtraEnv, qt.Not(qt.Contains), "GOMEMLIMIT=536870912")
+
+	// TypeScript apps only get the hints.
+	gen = newGen(&appfile.File{Lang: appfile.LangTS, ServiceResources: appFile.ServiceResources})
+	services, _, err = gen.ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+	gen.ReleaseAddresses()
+	c.Assert(services["foo"].MemoryLimit, qt.Equals, int64(512<<20))
+	c.Assert(services["foo"].ExtraEnv, qt.Not(qt.Contains), "GOMEMLIMIT=536870912")
+
+	gen = newGen(&appfile.File{ServiceResources: map[string]appfile.ServiceResources{
+		"foo": {MemoryLimitMB: -1},
+		"baz": {CPULimit: 1},
+	}})
+	err = gen.ValidateBuildSettings()
+	c.Assert(err, qt.ErrorMatches, `resource limits configured for unknown service "baz"\ninvalid memory limit -1 for service "foo": must be non-negative`)
+}
+
+func TestMinimal(t *testing.T) {
+	c := qt.New(t)
+
+	proxy := newTestProxy(c)
+
+	newGen := func(minimal bool) *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{
+			Svcs: []*meta.Service{{Name: "foo"}, {Name: "bar"}},
+			PubsubTopics: []*meta.PubSubTopic{{
+				Name:              "events",
+				DeliveryGuarantee: meta.PubSubTopic_AT_LEAST_ONCE,
+				Publishers:        []*meta.PubSubTopic_Publisher{{ServiceName: "bar"}},
+				Subscriptions:     []*meta.PubSubTopic_Subscription{{Name: "log", ServiceName: "bar"}},
+			}},
+		})
+		gen.Minimal = minimal
+		return gen
+	}
+
+	// Without minimal mode, services that don't use pubsub get an empty cluster.
+	gen := newGen(false)
+	services, _, err := gen.ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+	c.Assert(services["foo"].Runtime.MustGet().Infra.Resources.PubsubClusters, qt.HasLen, 1)
+	gen.ReleaseAddresses()
+
+	gen = newGen(true)
+	services, _, err = gen.ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+	c.Assert(services["foo"].Runtime.MustGet().Infra.Resources.PubsubClusters, qt.HasLen, 0)
+	c.Assert(services["bar"].Runtime.MustGet().Infra.Resources.PubsubClusters, qt.HasLen, 1)
+	gen.ReleaseAddresses()
+
+	// An app with only services has no infra clusters at all.
+	gen = newGen(true)
+	gen.md.PubsubTopics = nil
+	proc, err := gen.AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	res := proc.Runtime.MustGet().Infra.Resources
+	c.Assert(res.SqlClusters, qt.HasLen, 0)
+	c.Assert(res.PubsubClusters, qt.HasLen, 0)
+	c.Assert(res.RedisClusters, qt.HasLen, 0)
+	c.Assert(res.BucketClusters, qt.HasLen, 0)
+}
+
+func TestGlobalEnv(t *testing.T) {
+	c := qt.New(t)
+
+	proxy := newTestProxy(c)
+
+	newGen := func() *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{
+			Svcs:     []*meta.Service{{Name: "foo"}},
+			Gateways: []*meta.Gateway{{EncoreName: "api-gateway"}},
+		})
+		gen.Gateways = GatewayOptions{
+			Configs:  map[string]GatewayConfig{"api-gateway": {BaseURL: "http://localhost:4000"}},
+			ExtraEnv: map[string][]string{"api-gateway": {"LOG_SINK=file"}},
+		}
+		gen.GlobalEnv = []string{"TZ=UTC", "LOG_SINK=stdout"}
+		return gen
+	}
+
+	services, gateways, err := newGen().ProcPerService(proxy)
+	c.Assert(err, qt.IsNil)
+	c.Assert(services["foo"].ExtraEnv, qt.Contains, "TZ=UTC")
+	c.Assert(services["foo"].ExtraEnv, qt.Contains, "LOG_SINK=stdout")
+	// The gateway's own value wins over the global one.
+	c.Assert(gateways["api-gateway"].ExtraEnv, qt.DeepEquals, []string{"TZ=UTC", "LOG_SINK=file"})
+
+	_, services, gateways, err = newGen().ProcPerServiceWithNewRuntimeConfig(proxy)
+	c.Assert(err, qt.IsNil)
+	c.Assert(services["foo"].ExtraEnv, qt.Contains, "TZ=UTC")
+	c.Assert(gateways["api-gateway"].ExtraEnv, qt.Contains, "TZ=UTC")
+	c.Assert(gateways["api-gateway"].ExtraEnv, qt.Not(qt.Contains), "LOG_SINK=stdout")
+
+	proc, err := newGen().AllInOneProc()
+	c.Assert(err, qt.IsNil)
+	c.Assert(proc.ExtraEnv, qt.Contains, "TZ=UTC")
+
+	gen := newGen()
+	gen.RuntimeConfigFormat = RuntimeConfigV2
+	envs, err := gen.ForTests()
+	c.Assert(err, qt.IsNil)
+	c.Assert(envs, qt.Contains, "TZ=UTC")
+
+	gen = newGen()
+	gen.GlobalEnv = []string{"=1"}
+	_, err = gen.AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `invalid global environment variable "=1": must have the form KEY=value`)
+}
+
+func TestDryRun(t *testing.T) {
+	c := qt.New(t)
+
+	// Take the only port procs may listen on, so that
+	// any attempt to allocate a listen address fails.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, qt.IsNil)
+	defer ln.Close()
+	port := uint16(ln.Addr().(*net.TCPAddr).Port)
+
+	newGen := func() *RuntimeConfigGenerator {
+		gen := newTestGenerator(&meta.Data{
+			Svcs:         []*meta.Service{{Name: "foo", Databases: []string{"db"}}, {Name: "bar"}},
+			SqlDatabases: []*meta.SQLDatabase{{Name: "db"}},
+			Gateways:     []*meta.Gateway{{EncoreName: "api-gateway"}},
+		})
+		gen.PortRange = option.Some(PortRange{Min: port, Max: port})
+		return gen
+	}
+
+	_, err = newGen().AllInOneProc()
+	c.Assert(err, qt.ErrorMatches, `.*no free port in range.*`)
+
+	c.Assert(newGen().DryRun(), qt.IsNil)
+
+	// Configuration errors are still reported.
+	gen := newGen()
+	gen.SvcExtraEnv = map[string][]string{"unknown": {"A=1"}}
+	c.Assert(gen.DryRun(), qt.ErrorMatches, `extra environment variables configured for unknown service "unknown"`)
+}
+
+func TestInfraErrorsAreAggregated(t *testing.T) {
+	c := qt.New(t)
+
+	gen := newTestGenerator(&meta.Data{
+		Svcs:          []*meta.Service{{Name: "svc", Databases: []string{"orders", "users"}}},
+		SqlDatabases:  []*meta.SQLDatabase{{Name: "orders"}, {Name: "users"}},
+		CacheClusters: []*meta.CacheCluster{{Name: "sessions", Keyspaces: []*meta.CacheCluster_Keyspace{{Service: "svc"}}}},
+		Buckets:       []*meta.Bucket{{Name: "uploads"}},
+	})
+	gen.infraManager = testInfraManager{
+		sqlDBs: map[string]config.SQLDatabase{
+			"orders": {EncoreName: "orders", DatabaseName: "orders", User: "encore", SearchPath: []string{""}},
+		},
+		redisDBs: map[string]config.RedisDatabase{
+			"sessions": {EncoreName: "sessions", MaxLifetime: -time.Second},
+		},
+	}
+	gen.BucketKMSKeys = map[string]string{"uploads": "not-a-key"}
+	_, err := gen.AllInOneProc()
+	c.Assert(err, qt.IsNotNil)
+
+	msg := err.Error()
+	c.Assert(msg, qt.Contains, `invalid search path for database "orders"`)
+	c.Assert(msg, qt.Contains, `invalid connection pool config for cache cluster "sessions"`)
+	c.Assert(msg, qt.Contains, `invalid kms key "not-a-key" for bucket "uploads"`)
+	c.Assert(msg, qt.Not(qt.Contains), `"users"`)
+}
+
+func TestDeterministicRids(t *testing.T) {
+	c := qt.New(t)
+
+	md := &meta.Data{
+		Svcs: []*meta.Service{{Name: "foo"}},
+		Pkgs: []*meta.Package{
+			{RelPath: "foo", ServiceName: "foo", Secrets: []string{"A", "B", "C", "D"}},
+		},
+		SqlDatabases:  []*meta.SQLDatabase{{Name: "db"}},
+		CacheClusters: []*meta.CacheCluster{{Name: "cache"}},
+	}
+	build := func() *runtimev1.RuntimeConfig {
+		var n int
+		gen := newTestGenerator(md)
+		gen.RuntimeConfigFormat = RuntimeConfigV2
+		gen.DefinedSecrets = map[string]string{"A": "a", "B": "b", "C": "c"}
+		gen.SecretSources = map[string]SecretSource{"C": {Env: "C"}, "D": {Env: "D"}}
+		gen.NewRID = func() string {
+			n++
+			return fmt.Sprintf("res_%d", n)
+		}
+		proc, err := gen.AllInOneProc()
+		c.Assert(err, qt.IsNil)
+		conf := proc.Runtime.MustGet()
+		conf.Deployment.DeployedAt = nil
+		return conf
+	}
+
+	first := build()
+	c.Assert(first.Infra.Resources.RedisClusters[0].Rid, qt.Matches, `res_\d+`)
+	c.Assert(first.Infra.Resources.SqlClusters[0].Rid, qt.Matches, `res_\d+`)
+	c.Assert(first.Infra.Resources.AppSecrets, qt.HasLen, 4)
+	c.Assert(proto.Equal(first, build()), qt.IsTrue)
+}
+
+// decodeEnvData decodes data encoded with encodeEnvData.
+func decodeEnvData(c *qt.C, s string) []byte {
+	c.Helper()
+	codec, encoded, _ := strings.Cut(s, ":")
+	compressed, err := base64.StdEncoding.DecodeString(encoded)
+	c.Assert(err, qt.IsNil)
+
+	switch codec {
+	case "gzip":
+		r, err := gzip.NewReader(bytes.NewReader(compressed))
+		c.Assert(err, qt.IsNil)
+		data, err := io.ReadAll(r)
+		c.Assert(err, qt.IsNil)
+		return data
+	case "zstd":
+		r, err := zstd.NewReader(nil)
+		c.Assert(err, qt.IsNil)
+		defer r.Close()
+		data, err := r.DecodeAll(compressed, nil)
+		c.Assert(err, qt.IsNil)
+		return data
+	default:
+		c.Fatalf("unknown codec %q", codec)
+		return nil
+	}
+}
+
+// newTestGenerator returns a generator for md using the test app and infra manager.
+func newTestGenerator(md *meta.Data) *RuntimeConfigGenerator {
+	return &RuntimeConfigGenerator{md: md, app: testApp{}, infraManager: testInfraManager{}}
+}
+
+// newTestProxy returns a service proxy that's closed when the test ends.
+func newTestProxy(c *qt.C) *svcproxy.SvcProxy {
+	c.Helper()
+	proxy, err := svcproxy.New(context.Background(), zerolog.Nop())
+	c.Assert(err, qt.IsNil)
+	c.Cleanup(proxy.Close)
+	return proxy
+}
+
+type testApp struct {
+	appFile *appfile.File
+}
+
+func (testApp) PlatformID() string        { return "" }
+func (testApp) PlatformOrLocalID() string { return "test-app" }
+func (a testApp) GlobalCORS() (appfile.CORS, error) {
+	if a.appFile != nil && a.appFile.GlobalCORS != nil {
+		return *a.appFile.GlobalCORS, nil
+	}
+	return appfile.CORS{}, nil
+}
+func (a testApp) AppFile() (*appfile.File, error) {
+	if a.appFile != nil {
+		return a.appFile, nil
+	}
+	return &appfile.File{}, nil
+}
+func (testApp) BuildSettings() (appfile.Build, error) { return appfile.Build{}, nil }
+
+type testInfraManager struct {
+	pubsub       *config.PubsubProvider
+	signedURLTTL *time.Duration
+	subConfigs   map[string]config.PubsubSubscription // keyed by subscription name
+	sqlDBs       map[string]config.SQLDatabase        // keyed by database name
+	redisServer  *config.RedisServer
+	redisDBs     map[string]config.RedisDatabase // keyed by cache cluster name
+}
+
+func (testInfraManager) SQLServerConfig() (config.SQLServer, error) {
+	return config.SQLServer{Host: "localhost:5432"}, nil
+}
+
+func (m testInfraManager) PubSubProviderConfig() (config.PubsubProvider, error) {
+	if m.pubsub != nil {
+		return *m.pubsub, nil
+	}
+	return config.PubsubProvider{NSQ: &config.NSQProvider{Host: "localhost:4150"}}, nil
+}
+
+func (m testInfraManager) SQLDatabaseConfig(db *meta.SQLDatabase) (config.SQLDatabase, error) {
+	if cfg, ok := m.sqlDBs[db.Name]; ok {
+		return cfg, nil
+	}
+	return config.SQLDatabase{EncoreName: db.Name, DatabaseName: db.Name, User: "encore", Password: "pass"}, nil
+}
+
+func (testInfraManager) PubSubTopicConfig(topic *meta.PubSubTopic) (config.PubsubProvider, config.PubsubTopic, error) {
+	return config.PubsubProvider{}, config.PubsubTopic{}, nil
+}
+
+func (m testInfraManager) PubSubSubscriptionConfig(topic *meta.PubSubTopic, sub *meta.PubSubTopic_Subscription) (config.PubsubSubscription, error) {
+	return m.subConfigs[sub.Name], nil
+}
+
+func (m testInfraManager) RedisConfig(redis *meta.CacheCluster) (config.RedisServer, config.RedisDatabase, error) {
+	db, ok := m.redisDBs[redis.Name]
+	if !ok {
+		db = config.RedisDatabase{EncoreName: redis.Name}
+	}
+	if m.redisServer != nil {
+		return *m.redisServer, db, nil
+	}
+	return config.RedisServer{Host: "localhost:6379"}, db, nil
+}
+
+func (m testInfraManager) BucketProviderConfig() (config.BucketProvider, string, error) {
+	return config.BucketProvider{
+		GCS:                 &config.GCSBucketProvider{Endpoint: "http://localhost:4443"},
+		DefaultSignedURLTTL: m.signedURLTTL,
+	}, "http://localhost:4443", nil
+}
@@ -0,0 +1,183 @@
+package run
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	runtimev1 "encr.dev/proto/encore/runtime/v1"
+)
+
+// The resource types reported by DiffRuntimeConfigs.
+const (
+	ResourceTypeSQL     = "sql"
+	ResourceTypeRedis   = "redis"
+	ResourceTypePubSub  = "pubsub"
+	ResourceTypeBucket  = "bucket"
+	ResourceTypeSecret  = "secret"
+	ResourceTypeGateway = "gateway"
+)
+
+// DifferenceKind describes how a resource differs between two runtime configs.
+type DifferenceKind string
+
+const (
+	DifferenceAdded   DifferenceKind = "added"
+	DifferenceRemoved DifferenceKind = "removed"
+	DifferenceChanged DifferenceKind = "changed"
+)
+
+// Difference is a resource that differs between two runtime configs.
+type Difference struct {
+	// ResourceType is the type of the resource, such as ResourceTypeSQL.
+	ResourceType string
+	// Name identifies the resource within its type, such as the database name.
+	// Subscriptions are named "<topic>/<subscription>".
+	Name string
+	Kind DifferenceKind
+}
+
+// DiffRuntimeConfigs reports the resources that were added, removed or
+// changed going from a to b, ordered by resource type and name.
+//
+// Resources are identified by their Encore names rather than their resource ids,
+// so configs generated separately can be compared. Resource ids and references
+// to them are ignored when checking whether a resource changed.
+func DiffRuntimeConfigs(a, b *runtimev1.RuntimeConfig) ([]Difference, error) {
+	resA, err := resourcesByIdentity(a)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid config a")
+	}
+	resB, err := resourcesByIdentity(b)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid config b")
+	}
+
+	var diffs []Difference
+	for id, msgA := range resA {
+		msgB, ok := resB[id]
+		switch {
+		case !ok:
+			diffs = append(diffs, Difference{ResourceType: id.typ, Name: id.name, Kind: DifferenceRemoved})
+		case !proto.Equal(msgA, msgB):
+			diffs = append(diffs, Difference{ResourceType: id.typ, Name: id.name, Kind: DifferenceChanged})
+		}
+	}
+	for id := range resB {
+		if _, ok := resA[id]; !ok {
+			diffs = append(diffs, Difference{ResourceType: id.typ, Name: id.name, Kind: DifferenceAdded})
+		}
+	}
+
+	slices.SortFunc(diffs, func(x, y Difference) int {
+		return cmp.Or(cmp.Compare(x.ResourceType, y.ResourceType), cmp.Compare(x.Name, y.Name))
+	})
+	return diffs, nil
+}
+
+type resourceIdentity struct {
+	typ  string
+	name string
+}
+
+// resourcesByIdentity returns the resources of conf keyed by their identity.
+// Each resource includes the parts of its cluster that affect it,
+// such as the servers of a database, with resource ids cleared.
+func resourcesByIdentity(conf *runtimev1.RuntimeConfig) (map[resourceIdentity]proto.Message, error) {
+	res := make(map[resourceIdentity]proto.Message)
+	add := func(typ, name string, msg proto.Message) error {
+		id := resourceIdentity{typ, name}
+		if _, ok := res[id]; ok {
+			return errors.Newf("duplicate %s resource %q", typ, name)
+		}
+		clearRIDs(msg.ProtoReflect())
+		res[id] = msg
+		return nil
+	}
+
+	r := conf.GetInfra().GetResources()
+	for _, cluster := range r.GetSqlClusters() {
+		for _, db := range cluster.Databases {
+			msg := &runtimev1.SQLCluster{
+				Servers:   cluster.Servers,
+				Databases: []*runtimev1.SQLDatabase{db},
+				SshTunnel: cluster.SshTunnel,
+			}
+			if err := add(ResourceTypeSQL, db.EncoreName, proto.Clone(msg)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, cluster := range r.GetRedisClusters() {
+		for _, db := range cluster.Databases {
+			msg := &runtimev1.RedisCluster{
+				Servers:   cluster.Servers,
+				Databases: []*runtimev1.RedisDatabase{db},
+			}
+			if err := add(ResourceTypeRedis, db.EncoreName, proto.Clone(msg)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, cluster := range r.GetPubsubClusters() {
+		for _, topic := range cluster.Topics {
+			msg := proto.Clone(cluster).(*runtimev1.PubSubCluster)
+			msg.Topics = []*runtimev1.PubSubTopic{topic}
+			msg.Subscriptions = nil
+			if err := add(ResourceTypePubSub, topic.EncoreName, msg); err != nil {
+				return nil, err
+			}
+		}
+		for _, sub := range cluster.Subscriptions {
+			msg := proto.Clone(cluster).(*runtimev1.PubSubCluster)
+			msg.Topics = nil
+			msg.Subscriptions = []*runtimev1.PubSubSubscription{sub}
+			if err := add(ResourceTypePubSub, sub.TopicEncoreName+"/"+sub.SubscriptionEncoreName, msg); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, cluster := range r.GetBucketClusters() {
+		for _, bkt := range cluster.Buckets {
+			msg := proto.Clone(cluster).(*runtimev1.BucketCluster)
+			msg.Buckets = []*runtimev1.Bucket{bkt}
+			if err := add(ResourceTypeBucket, bkt.EncoreName, msg); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, secret := range r.GetAppSecrets() {
+		if err := add(ResourceTypeSecret, secret.EncoreName, proto.Clone(secret)); err != nil {
+			return nil, err
+		}
+	}
+	for _, gw := range r.GetGateways() {
+		if err := add(ResourceTypeGateway, gw.EncoreName, proto.Clone(gw)); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// clearRIDs recursively clears resource ids, and references to them, in m.
+func clearRIDs(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap() &&
+			(fd.Name() == "rid" || strings.HasSuffix(string(fd.Name()), "_rid")):
+			m.Clear(fd)
+		case fd.Kind() == protoreflect.MessageKind && fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				clearRIDs(list.Get(i).Message())
+			}
+		case fd.Kind() == protoreflect.MessageKind && !fd.IsMap():
+			clearRIDs(v.Message())
+		}
+		return true
+	})
+}
@@ -0,0 +1,56 @@
+package run
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	runtimev1 "encr.dev/proto/encore/runtime/v1"
+)
+
+func TestDiffRuntimeConfigs(t *testing.T) {
+	c := qt.New(t)
+
+	generate := func(topics ...string) *runtimev1.RuntimeConfig {
+		md := &meta.Data{
+			Svcs:         []*meta.Service{{Name: "foo", Databases: []string{"orders"}}},
+			SqlDatabases: []*meta.SQLDatabase{{Name: "orders"}},
+		}
+		for _, topic := range topics {
+			md.PubsubTopics = append(md.PubsubTopics, &meta.PubSubTopic{
+				Name:       topic,
+				Publishers: []*meta.PubSubTopic_Publisher{{ServiceName: "foo"}},
+			})
+		}
+		gen := newTestGenerator(md)
+		gen.DefinedSecrets = map[string]string{}
+		_, conf, err := gen.AllInOneProcWithRuntimeConfig()
+		c.Assert(err, qt.IsNil)
+		return conf
+	}
+
+	// Separately generated configs have different resource ids,
+	// which must not be reported as differences.
+	a, b := generate("created"), generate("created", "shipped")
+	diffs, err := DiffRuntimeConfigs(a, b)
+	c.Assert(err, qt.IsNil)
+	c.Assert(diffs, qt.DeepEquals, []Difference{
+		{ResourceType: ResourceTypePubSub, Name: "shipped", Kind: DifferenceAdded},
+	})
+
+	diffs, err = DiffRuntimeConfigs(b, a)
+	c.Assert(err, qt.IsNil)
+	c.Assert(diffs, qt.DeepEquals, []Difference{
+		{ResourceType: ResourceTypePubSub, Name: "shipped", Kind: DifferenceRemoved},
+	})
+
+	// Changing a resource reports it as changed.
+	b = generate("created")
+	b.Infra.Resources.SqlClusters[0].Servers[0].Host = "db.example.com:5432"
+	diffs, err = DiffRuntimeConfigs(a, b)
+	c.Assert(err, qt.IsNil)
+	c.Assert(diffs, qt.DeepEquals, []Difference{
+		{ResourceType: ResourceTypeSQL, Name: "orders", Kind: DifferenceChanged},
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/rs/xid"
 
+	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/exported/experiments"
 	"encr.dev/cli/daemon/apps"
 	"encr.dev/cli/daemon/namespace"
@@ -221,25 +222,27 @@ func (mgr *Manager) testSpec(ctx context.Context, bld builder.Impl, expSet *expe
 
 	authKey := genAuthKey()
 	configGen := &RuntimeConfigGenerator{
-		app:               params.App,
-		infraManager:      rm,
-		md:                parse.Meta,
-		AppID:             option.Some(params.App.PlatformOrLocalID()),
-		EnvID:             option.Some("test"),
-		TraceEndpoint:     option.Some(fmt.Sprintf("http://localhost:%d/trace", mgr.RuntimePort)),
-		AuthKey:           authKey,
-		Gateways:          gateways,
-		DefinedSecrets:    secrets,
-		SvcConfigs:        cfg.Configs,
-		EnvName:           option.Some("test"),
-		EnvType:           option.Some(runtimev1.Environment_TYPE_TEST),
-		DeployID:          option.Some(fmt.Sprintf("clitest_%s", xid.New().String())),
-		IncludeMeta:       bld.NeedsMeta(),
-		MetaPath:          metaPath,
-		RuntimeConfigPath: runtimeConfigPath,
+		app:                 params.App,
+		infraManager:        rm,
+		md:                  parse.Meta,
+		AppID:               option.Some(params.App.PlatformOrLocalID()),
+		EnvID:               option.Some("test"),
+		Tracing:             TracingOptions{Endpoint: option.Some(fmt.Sprintf("http://localhost:%d/trace", mgr.RuntimePort))},
+		AuthKeys:            []config.EncoreAuthKey{authKey},
+		Gateways:            GatewayOptions{Configs: gateways},
+		DefinedSecrets:      secrets,
+		SvcConfigs:          cfg.Configs,
+		EnvName:             option.Some("test"),
+		EnvType:             option.Some(runtimev1.Environment_TYPE_TEST),
+		DeployID:            option.Some(fmt.Sprintf("clitest_%s", xid.New().String())),
+		IncludeMeta:         bld.NeedsMeta(),
+		MetaPath:            metaPath,
+		RuntimeConfigPath:   runtimeConfigPath,
+		RuntimeConfigFormat: runtimeConfigFormat(bld.UseNewRuntimeConfig()),
+		VCS:                 option.Some(vcsRevision),
 	}
 
-	env, err := configGen.ForTests(bld.UseNewRuntimeConfig())
+	env, err := configGen.ForTests()
 	if err != nil {
 		return nil, err
 	}
@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/tailscale/hujson"
 	"mvdan.cc/sh/v3/expand"
@@ -70,6 +71,133 @@ type File struct {
 	// LogLevel is the minimum log level for the app.
 	// If empty it defaults to "trace".
 	LogLevel string `json:"log_level,omitempty"`
+
+	// LogFormat is the format to write logs in: "json" or "console".
+	// If empty the runtime's default format is used.
+	LogFormat string `json:"log_format,omitempty"`
+
+	// ServiceLogs overrides the log configuration of specific services,
+	// keyed by service name.
+	ServiceLogs map[string]ServiceLog `json:"service_logs,omitempty"`
+
+	// ServiceResources sets resource limit hints for specific services
+	// running in their own process, keyed by service name.
+	ServiceResources map[string]ServiceResources `json:"service_resources,omitempty"`
+
+	// ServiceRequestTimeouts sets the default timeout for requests
+	// handled by specific services, keyed by service name.
+	// Zero means requests don't time out.
+	ServiceRequestTimeouts map[string]Duration `json:"service_request_timeouts,omitempty"`
+
+	// GracefulShutdown configures the graceful shutdown timings for the app.
+	// If nil the default timings are used.
+	GracefulShutdown *GracefulShutdown `json:"graceful_shutdown,omitempty"`
+
+	// ServiceCalls configures the connections services use to call other services.
+	// If nil the default settings are used.
+	ServiceCalls *ServiceCalls `json:"service_calls,omitempty"`
+
+	// Gateways configures the app's API gateways, keyed by gateway name.
+	Gateways map[string]Gateway `json:"gateways,omitempty"`
+}
+
+// ServiceLog configures the logging of a service.
+// Unset values use the app-wide configuration.
+type ServiceLog struct {
+	// Level is the minimum log level for the service.
+	Level string `json:"level,omitempty"`
+
+	// Format is the format to write logs in: "json" or "console".
+	Format string `json:"format,omitempty"`
+}
+
+// ServiceResources are resource limit hints for a service's process.
+// Zero values mean no limit.
+type ServiceResources struct {
+	// MemoryLimitMB is the memory limit in mebibytes.
+	MemoryLimitMB int64 `json:"memory_limit_mb,omitempty"`
+
+	// CPULimit is the number of CPUs the service may use, like 0.5 or 2.
+	CPULimit float64 `json:"cpu_limit,omitempty"`
+}
+
+// ServiceCalls configures the connections used for calls between services.
+// Unset values use the defaults.
+type ServiceCalls struct {
+	// MaxIdleConns is the maximum number of idle connections
+	// to keep open per service. Defaults to 100.
+	MaxIdleConns *int `json:"max_idle_conns,omitempty"`
+
+	// IdleConnTimeout is how long idle connections are kept open
+	// before being closed. Defaults to 90s.
+	IdleConnTimeout *Duration `json:"idle_conn_timeout,omitempty"`
+}
+
+// Gateway configures an API gateway.
+type Gateway struct {
+	// RateLimit limits the rate of requests to the gateway.
+	// If nil requests are not rate limited.
+	RateLimit *RateLimit `json:"rate_limit,omitempty"`
+
+	// MaxBodySize is the maximum size of request bodies in bytes.
+	// Zero means unlimited. If nil the runtime default is used.
+	MaxBodySize *int64 `json:"max_body_size,omitempty"`
+}
+
+// RateLimit configures request rate limiting.
+type RateLimit struct {
+	// Requests is the number of requests allowed per window.
+	Requests int `json:"requests"`
+
+	// Window is the window requests are counted over. Defaults to 1s.
+	Window *Duration `json:"window,omitempty"`
+
+	// Burst is the number of requests that may be made at once.
+	// Defaults to Requests.
+	Burst *int `json:"burst,omitempty"`
+
+	// PerIP limits the rate of requests per client IP address
+	// rather than across all clients.
+	PerIP bool `json:"per_ip,omitempty"`
+}
+
+// GracefulShutdown configures how long the app is given to shut down gracefully.
+// Unset values use the defaults.
+type GracefulShutdown struct {
+	// Total is how long the total shutdown is allowed to take
+	// before the process forcibly exits. Defaults to 10s.
+	Total *Duration `json:"total,omitempty"`
+
+	// ShutdownHooks is how long before Total runs out that the context
+	// passed to shutdown hooks is canceled. Defaults to 4s.
+	ShutdownHooks *Duration `json:"shutdown_hooks,omitempty"`
+
+	// Handlers is how long before Total runs out that the context
+	// passed to API and PubSub handlers is canceled. Defaults to 2s.
+	Handlers *Duration `json:"handlers,omitempty"`
+}
+
+// Duration is a time.Duration that is represented in JSON
+// as a duration string, like "30s" or "1m30s".
+type Duration time.Duration
+
+// UnmarshalJSON parses a duration string.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return fmt.Errorf("invalid duration: %v", err)
+	}
+	dur, err := time.ParseDuration(str)
+	if err != nil {
+		return fmt.Errorf("invalid duration: %v", err)
+	}
+	*d = Duration(dur)
+	return nil
+}
+
+// MarshalJSON encodes the duration as a duration string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
 }
 
 type Build struct {
@@ -83,6 +211,13 @@ type Build struct {
 	// WorkerPooling enables worker pooling for Encore.ts.
 	WorkerPooling bool `json:"worker_pooling,omitempty"`
 
+	// WorkerThreads is the number of worker threads to use
+	// when worker pooling is enabled. Zero means automatic.
+	WorkerThreads int `json:"worker_threads,omitempty"`
+
+	// ServiceWorkerThreads overrides WorkerThreads for specific services.
+	ServiceWorkerThreads map[string]int `json:"service_worker_threads,omitempty"`
+
 	// Hooks configures hooks for the build process.
 	Hooks Hooks `json:"hooks,omitempty"`
 }
@@ -39,9 +39,18 @@ type Builder struct {
 	// unless a deployment overrides it.
 	defaultGracefulShutdown *runtimev1.GracefulShutdown
 
+	// externalHTTPDeps are the external HTTP dependencies available to all deployments.
+	externalHTTPDeps []*runtimev1.ExternalHTTPDependency
+
 	defaultDeployID   string
 	defaultDeployedAt time.Time
 
+	vcsRevision    string
+	vcsUncommitted bool
+
+	// transforms are applied to each built runtime config, in order.
+	transforms []func(*runtimev1.RuntimeConfig) error
+
 	deployments map[string]*Deployment
 	services    map[string]*runtimev1.HostedService
 }
@@ -74,6 +83,11 @@ func (b *Builder) DefaultGracefulShutdown(s *runtimev1.GracefulShutdown) *Builde
 	return b
 }
 
+func (b *Builder) ExternalHTTPDependencies(deps []*runtimev1.ExternalHTTPDependency) *Builder {
+	b.externalHTTPDeps = deps
+	return b
+}
+
 func (b *Builder) AuthMethods(m []*runtimev1.ServiceAuth) *Builder {
 	b.authMethods = m
 	return b
@@ -89,6 +103,21 @@ func (b *Builder) DeployedAt(t time.Time) *Builder {
 	return b
 }
 
+// VCSRevision sets the version control revision the deployments were built from.
+func (b *Builder) VCSRevision(revision string, uncommitted bool) *Builder {
+	b.vcsRevision = revision
+	b.vcsUncommitted = uncommitted
+	return b
+}
+
+// Transform registers fn to post-process each runtime config before it's returned
+// from BuildRuntimeConfig. Transforms run in the order they were registered,
+// and an error from any of them fails the build.
+func (b *Builder) Transform(fn func(*runtimev1.RuntimeConfig) error) *Builder {
+	b.transforms = append(b.transforms, fn)
+	return b
+}
+
 func (b *Builder) TracingProvider(p *runtimev1.TracingProvider) {
 	b.TracingProviderFn(p.Rid, tofn(p))
 }
@@ -148,6 +177,22 @@ type Deployment struct {
 
 	hostedGateways     []string
 	hostedServiceNames []string
+
+	// Databases this deployment only reads from.
+	readOnlyDBs []string
+
+	// Overrides the size of SQL connection pools, if set.
+	sqlPoolSize option.Option[sqlPoolSize]
+
+	// Whether to omit infrastructure clusters that have no resources.
+	minimal bool
+
+	// The percentage of the hosted services' traffic this deployment receives, if set.
+	trafficWeight option.Option[uint32]
+}
+
+type sqlPoolSize struct {
+	min, max int32
 }
 
 // DeployID sets the deploy id.
@@ -188,6 +233,21 @@ func (d *Deployment) OverrideGracefulShutdown(s *runtimev1.GracefulShutdown) *De
 	return d
 }
 
+// ReadOnlyDatabases marks the given databases as only being read from by this deployment,
+// which makes it connect to them using read-only connection pools.
+// It appends and doesn't overwrite any existing read-only databases.
+func (d *Deployment) ReadOnlyDatabases(names ...string) *Deployment {
+	d.readOnlyDBs = append(d.readOnlyDBs, names...)
+	return d
+}
+
+// SQLPoolSize overrides the minimum and maximum number of connections
+// of this deployment's SQL connection pools.
+func (d *Deployment) SQLPoolSize(minConns, maxConns int32) *Deployment {
+	d.sqlPoolSize = option.Some(sqlPoolSize{min: minConns, max: maxConns})
+	return d
+}
+
 func (d *Deployment) ServiceDiscovery(sd *runtimev1.ServiceDiscovery) *Deployment {
 	d.sd = sd
 	return d
@@ -198,15 +258,53 @@ func (d *Deployment) ReduceWithMeta(md *meta.Data) *Deployment {
 	return d
 }
 
+// Minimal omits infrastructure clusters that contain no resources
+// from the runtime config, so unused subsystems don't show up at all.
+func (d *Deployment) Minimal() *Deployment {
+	d.minimal = true
+	return d
+}
+
+// TrafficWeight sets the percentage (0-100) of the hosted services' traffic
+// this deployment receives, for splitting traffic between deployments of the same services.
+func (d *Deployment) TrafficWeight(weight uint32) *Deployment {
+	d.trafficWeight = option.Some(weight)
+	return d
+}
+
+// validateTrafficWeight checks that the deployment's traffic weight is a percentage,
+// and that together with other deployments hosting the same services it doesn't exceed 100.
+func (d *Deployment) validateTrafficWeight() error {
+	weight, ok := d.trafficWeight.Get()
+	if !ok {
+		return nil
+	} else if weight > 100 {
+		return errors.Newf("invalid traffic weight %d for deployment %q: must be between 0 and 100", weight, d.rid)
+	}
+
+	for _, svc := range d.hostedServiceNames {
+		total := 0
+		for _, other := range d.b.deployments {
+			if w, ok := other.trafficWeight.Get(); ok && slices.Contains(other.hostedServiceNames, svc) {
+				total += int(w)
+			}
+		}
+		if total > 100 {
+			return errors.Newf("traffic weights of the deployments hosting service %q add up to %d, more than 100", svc, total)
+		}
+	}
+	return nil
+}
+
 func (d *Deployment) BuildRuntimeConfig() (*runtimev1.RuntimeConfig, error) {
 	b := d.b
 
-	infra, err := b.Infra.get()
+	infra, err := d.infra()
 	if err != nil {
 		return nil, err
 	}
-	if reduced, ok := d.reduceWith.Get(); ok {
-		infra = reduceForServices(infra, reduced, d.hostedServiceNames)
+	if err := d.validateTrafficWeight(); err != nil {
+		return nil, err
 	}
 
 	graceful := d.gracefulShutdown.GetOrElse(d.b.defaultGracefulShutdown)
@@ -285,6 +383,11 @@ func (d *Deployment) BuildRuntimeConfig() (*runtimev1.RuntimeConfig, error) {
 		DeployId:           d.deployID.GetOrElse(b.defaultDeployID),
 		DeployedAt:         timestamppb.New(d.deployedAt.GetOrElse(b.defaultDeployedAt)),
 		Metrics:            metrics,
+		VcsRevision:        option.AsOptional(b.vcsRevision).PtrOrNil(),
+		VcsUncommitted:     b.vcsUncommitted,
+		TrafficWeight:      d.trafficWeight.PtrOrNil(),
+
+		ExternalHttpDependencies: b.externalHTTPDeps,
 	}
 
 	cfg := &runtimev1.RuntimeConfig{
@@ -296,8 +399,127 @@ func (d *Deployment) BuildRuntimeConfig() (*runtimev1.RuntimeConfig, error) {
 
 	// Deep-clone the protobuf to avoid subsequent mutations from modifying this one.
 	cfg = cloneProto(cfg)
+	if b.err != nil {
+		return cfg, b.err
+	}
+
+	for _, fn := range b.transforms {
+		if err := fn(cfg); err != nil {
+			return nil, errors.Wrap(err, "transform runtime config")
+		}
+	}
+	return cfg, nil
+}
+
+// infra returns the infrastructure configuration for this deployment.
+func (d *Deployment) infra() (*runtimev1.Infrastructure, error) {
+	infra, err := d.b.Infra.get()
+	if err != nil {
+		return nil, err
+	}
+	if reduced, ok := d.reduceWith.Get(); ok {
+		infra = reduceForServices(infra, reduced, d.hostedServiceNames, d.hostedGateways)
+	}
+	if len(d.readOnlyDBs) > 0 || d.sqlPoolSize.Present() {
+		infra = cloneProto(infra)
+		for _, cluster := range infra.Resources.SqlClusters {
+			for _, db := range cluster.Databases {
+				readOnly := slices.Contains(d.readOnlyDBs, db.EncoreName)
+				for _, pool := range db.ConnPools {
+					if readOnly {
+						pool.IsReadonly = true
+					}
+					if size, ok := d.sqlPoolSize.Get(); ok {
+						pool.MinConnections, pool.MaxConnections = size.min, size.max
+					}
+				}
+			}
+		}
+	}
+
+	if d.minimal {
+		infra = omitEmptyClusters(infra)
+	}
+	return infra, nil
+}
+
+// ResourceSummary describes the resources a deployment connects to.
+type ResourceSummary struct {
+	SQLDatabases int
+	SQLConnPools int
+	// SQLMaxConns is the sum of the max connections of all SQL connection pools.
+	SQLMaxConns int
+	// SQLMaxConnsByCluster is SQLMaxConns broken down by SQL cluster rid.
+	SQLMaxConnsByCluster map[string]int
+
+	PubSubTopics        int
+	PubSubSubscriptions int
+	RedisDatabases      int
+	Buckets             int
+}
+
+// ResourceSummary returns the number of resources of each type the deployment uses.
+// If the deployment is reduced with [Deployment.ReduceWithMeta] the summary only
+// includes the resources used by the hosted services.
+func (d *Deployment) ResourceSummary() (ResourceSummary, error) {
+	infra, err := d.infra()
+	if err != nil {
+		return ResourceSummary{}, err
+	}
+
+	// Databases aren't removed when reducing the infrastructure,
+	// so only count the ones the hosted services use.
+	usesDB := func(string) bool { return true }
+	if md, ok := d.reduceWith.Get(); ok {
+		used := ResourcesUsedBy(md, d.hostedServiceNames...)
+		usesDB = func(name string) bool { return slices.Contains(used.Databases, name) }
+	}
+
+	sum := ResourceSummary{SQLMaxConnsByCluster: make(map[string]int)}
+	for _, cluster := range infra.Resources.SqlClusters {
+		for _, db := range cluster.Databases {
+			if !usesDB(db.EncoreName) {
+				continue
+			}
+			sum.SQLDatabases++
+			sum.SQLConnPools += len(db.ConnPools)
+			for _, pool := range db.ConnPools {
+				sum.SQLMaxConns += int(pool.MaxConnections)
+				sum.SQLMaxConnsByCluster[cluster.Rid] += int(pool.MaxConnections)
+			}
+		}
+	}
+	for _, cluster := range infra.Resources.PubsubClusters {
+		sum.PubSubTopics += len(cluster.Topics)
+		sum.PubSubSubscriptions += len(cluster.Subscriptions)
+	}
+	for _, cluster := range infra.Resources.RedisClusters {
+		sum.RedisDatabases += len(cluster.Databases)
+	}
+	for _, cluster := range infra.Resources.BucketClusters {
+		sum.Buckets += len(cluster.Buckets)
+	}
+	return sum, nil
+}
 
-	return cfg, b.err
+// omitEmptyClusters returns a copy of infra without any
+// clusters that don't contain any resources.
+func omitEmptyClusters(infra *runtimev1.Infrastructure) *runtimev1.Infrastructure {
+	infra = cloneProto(infra)
+	res := infra.Resources
+	res.SqlClusters = slices.DeleteFunc(res.SqlClusters, func(c *runtimev1.SQLCluster) bool {
+		return len(c.Databases) == 0
+	})
+	res.PubsubClusters = slices.DeleteFunc(res.PubsubClusters, func(c *runtimev1.PubSubCluster) bool {
+		return len(c.Topics) == 0 && len(c.Subscriptions) == 0
+	})
+	res.RedisClusters = slices.DeleteFunc(res.RedisClusters, func(c *runtimev1.RedisCluster) bool {
+		return len(c.Databases) == 0
+	})
+	res.BucketClusters = slices.DeleteFunc(res.BucketClusters, func(c *runtimev1.BucketCluster) bool {
+		return len(c.Buckets) == 0
+	})
+	return infra
 }
 
 func (b *Builder) setErr(err error) {
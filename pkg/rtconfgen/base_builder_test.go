@@ -0,0 +1,200 @@
+package rtconfgen
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	runtimev1 "encr.dev/proto/encore/runtime/v1"
+)
+
+func TestDeployment_ResourceSummary(t *testing.T) {
+	c := qt.New(t)
+
+	md := &meta.Data{
+		Svcs: []*meta.Service{
+			{Name: "foo", Databases: []string{"foo"}},
+			{Name: "bar", Databases: []string{"foo", "bar"}},
+		},
+		PubsubTopics: []*meta.PubSubTopic{
+			{
+				Name:          "signups",
+				Publishers:    []*meta.PubSubTopic_Publisher{{ServiceName: "foo"}},
+				Subscriptions: []*meta.PubSubTopic_Subscription{{Name: "welcome", ServiceName: "bar"}},
+			},
+			{
+				Name:          "orders",
+				Publishers:    []*meta.PubSubTopic_Publisher{{ServiceName: "bar"}},
+				Subscriptions: []*meta.PubSubTopic_Subscription{{Name: "fulfill", ServiceName: "bar"}},
+			},
+		},
+	}
+
+	b := NewBuilder()
+	sqlCluster := b.Infra.SQLCluster(&runtimev1.SQLCluster{Rid: "sql-cluster"})
+	for _, name := range []string{"foo", "bar"} {
+		sqlCluster.SQLDatabase(&runtimev1.SQLDatabase{Rid: "db:" + name, EncoreName: name}).
+			AddConnectionPool(&runtimev1.SQLConnectionPool{MaxConnections: 30})
+	}
+	pubsubCluster := b.Infra.PubSubCluster(&runtimev1.PubSubCluster{Rid: "pubsub-cluster"})
+	for _, topic := range md.PubsubTopics {
+		pubsubCluster.PubSubTopic(&runtimev1.PubSubTopic{Rid: "topic:" + topic.Name, EncoreName: topic.Name})
+		for _, sub := range topic.Subscriptions {
+			pubsubCluster.PubSubSubscription(&runtimev1.PubSubSubscription{
+				Rid:                    "sub:" + sub.Name,
+				TopicEncoreName:        topic.Name,
+				SubscriptionEncoreName: sub.Name,
+			})
+		}
+	}
+
+	sum, err := b.Deployment("foo").HostsServices("foo").ReduceWithMeta(md).ResourceSummary()
+	c.Assert(err, qt.IsNil)
+	c.Assert(sum, qt.DeepEquals, ResourceSummary{
+		SQLDatabases: 1,
+		SQLConnPools: 1,
+		SQLMaxConns:  30,
+		PubSubTopics: 1,
+
+		SQLMaxConnsByCluster: map[string]int{"sql-cluster": 30},
+	})
+
+	sum, err = b.Deployment("bar").HostsServices("bar").SQLPoolSize(2, 10).ReduceWithMeta(md).ResourceSummary()
+	c.Assert(err, qt.IsNil)
+	c.Assert(sum, qt.DeepEquals, ResourceSummary{
+		SQLDatabases:        2,
+		SQLConnPools:        2,
+		SQLMaxConns:         20,
+		PubSubTopics:        1,
+		PubSubSubscriptions: 2,
+
+		SQLMaxConnsByCluster: map[string]int{"sql-cluster": 20},
+	})
+
+	// Without reducing, the summary covers all resources.
+	sum, err = b.Deployment("all").HostsServices("foo", "bar").ResourceSummary()
+	c.Assert(err, qt.IsNil)
+	c.Assert(sum, qt.DeepEquals, ResourceSummary{
+		SQLDatabases:        2,
+		SQLConnPools:        2,
+		SQLMaxConns:         60,
+		PubSubTopics:        2,
+		PubSubSubscriptions: 2,
+
+		SQLMaxConnsByCluster: map[string]int{"sql-cluster": 60},
+	})
+}
+
+func TestDeployment_TrafficWeight(t *testing.T) {
+	c := qt.New(t)
+
+	b := NewBuilder()
+	stable := b.Deployment("stable").HostsServices("foo").TrafficWeight(90)
+	canary := b.Deployment("canary").HostsServices("foo").TrafficWeight(10)
+
+	cfg, err := stable.BuildRuntimeConfig()
+	c.Assert(err, qt.IsNil)
+	c.Assert(cfg.Deployment.GetTrafficWeight(), qt.Equals, uint32(90))
+	cfg, err = canary.BuildRuntimeConfig()
+	c.Assert(err, qt.IsNil)
+	c.Assert(cfg.Deployment.GetTrafficWeight(), qt.Equals, uint32(10))
+
+	// Deployments without a weight don't carry one.
+	cfg, err = b.Deployment("other").HostsServices("bar").BuildRuntimeConfig()
+	c.Assert(err, qt.IsNil)
+	c.Assert(cfg.Deployment.TrafficWeight, qt.IsNil)
+
+	canary.TrafficWeight(20)
+	_, err = canary.BuildRuntimeConfig()
+	c.Assert(err, qt.ErrorMatches, `traffic weights of the deployments hosting service "foo" add up to 110, more than 100`)
+
+	_, err = b.Deployment("invalid").HostsServices("baz").TrafficWeight(101).BuildRuntimeConfig()
+	c.Assert(err, qt.ErrorMatches, `invalid traffic weight 101 for deployment "invalid": must be between 0 and 100`)
+}
+
+func TestBuilder_Transform(t *testing.T) {
+	c := qt.New(t)
+
+	var calls []string
+	b := NewBuilder().
+		Transform(func(conf *runtimev1.RuntimeConfig) error {
+			calls = append(calls, "first")
+			conf.Deployment.DeployId = "transformed"
+			return nil
+		}).
+		Transform(func(conf *runtimev1.RuntimeConfig) error {
+			calls = append(calls, "second")
+			conf.Deployment.DeployId += "-twice"
+			return nil
+		})
+	cfg, err := b.Deployment("deploy").BuildRuntimeConfig()
+	c.Assert(err, qt.IsNil)
+	c.Assert(cfg.Deployment.DeployId, qt.Equals, "transformed-twice")
+	c.Assert(calls, qt.DeepEquals, []string{"first", "second"})
+
+	b.Transform(func(*runtimev1.RuntimeConfig) error { return errors.New("boom") })
+	_, err = b.Deployment("deploy").BuildRuntimeConfig()
+	c.Assert(err, qt.ErrorMatches, `transform runtime config: boom`)
+}
+
+func TestDeployment_BucketAccessMode(t *testing.T) {
+	c := qt.New(t)
+
+	md := &meta.Data{
+		Svcs: []*meta.Service{
+			{Name: "uploader", Buckets: []*meta.BucketUsage{{
+				Bucket:     "images",
+				Operations: []meta.BucketUsage_Operation{meta.BucketUsage_WRITE_OBJECT, meta.BucketUsage_LIST_OBJECTS},
+			}}},
+			{Name: "viewer", Buckets: []*meta.BucketUsage{
+				{
+					Bucket:     "images",
+					Operations: []meta.BucketUsage_Operation{meta.BucketUsage_READ_OBJECT_CONTENTS, meta.BucketUsage_SIGNED_DOWNLOAD_URL},
+				},
+				// Usages without recorded operations get write access.
+				{Bucket: "cache"},
+			}},
+		},
+		Buckets: []*meta.Bucket{{Name: "images"}, {Name: "cache"}},
+	}
+
+	b := NewBuilder()
+	cluster := b.Infra.BucketCluster(&runtimev1.BucketCluster{Rid: "bucket-cluster"})
+	for _, bkt := range md.Buckets {
+		cluster.Bucket(&runtimev1.Bucket{Rid: "bucket:" + bkt.Name, EncoreName: bkt.Name, CloudName: bkt.Name})
+	}
+
+	accessModes := func(d *Deployment) map[string]runtimev1.Bucket_AccessMode {
+		cfg, err := d.BuildRuntimeConfig()
+		c.Assert(err, qt.IsNil)
+		modes := make(map[string]runtimev1.Bucket_AccessMode)
+		for _, cluster := range cfg.Infra.Resources.BucketClusters {
+			for _, bkt := range cluster.Buckets {
+				modes[bkt.EncoreName] = bkt.AccessMode
+			}
+		}
+		return modes
+	}
+
+	c.Assert(accessModes(b.Deployment("viewer").HostsServices("viewer").ReduceWithMeta(md)), qt.DeepEquals, map[string]runtimev1.Bucket_AccessMode{
+		"images": runtimev1.Bucket_ACCESS_MODE_READ_ONLY,
+		"cache":  runtimev1.Bucket_ACCESS_MODE_READ_WRITE,
+	})
+	c.Assert(accessModes(b.Deployment("uploader").HostsServices("uploader").ReduceWithMeta(md)), qt.DeepEquals, map[string]runtimev1.Bucket_AccessMode{
+		"images": runtimev1.Bucket_ACCESS_MODE_READ_WRITE,
+	})
+
+	// A deployment hosting both services can write to the bucket.
+	c.Assert(accessModes(b.Deployment("all").HostsServices("uploader", "viewer").ReduceWithMeta(md)), qt.DeepEquals, map[string]runtimev1.Bucket_AccessMode{
+		"images": runtimev1.Bucket_ACCESS_MODE_READ_WRITE,
+		"cache":  runtimev1.Bucket_ACCESS_MODE_READ_WRITE,
+	})
+
+	// Without reducing, the access mode is left unspecified.
+	c.Assert(accessModes(b.Deployment("unreduced").HostsServices("viewer")), qt.DeepEquals, map[string]runtimev1.Bucket_AccessMode{
+		"images": runtimev1.Bucket_ACCESS_MODE_UNSPECIFIED,
+		"cache":  runtimev1.Bucket_ACCESS_MODE_UNSPECIFIED,
+	})
+}
@@ -202,13 +202,19 @@ func (c *legacyConverter) Convert() (*config.Runtime, error) {
 					}
 					if primary.TlsConfig != nil {
 						candidateServer.ServerCACert = primary.TlsConfig.GetServerCaCert()
+						candidateServer.ServerName = primary.TlsConfig.GetServerName()
+					}
+					if primary.Driver == runtimev1.SQLServer_DRIVER_MYSQL {
+						candidateServer.Driver = "mysql"
 					}
 
 					serverIdx := slices.IndexFunc(cfg.SQLServers, func(s *config.SQLServer) bool {
 						return s.Host == candidateServer.Host &&
 							s.ServerCACert == candidateServer.ServerCACert &&
 							s.ClientCert == candidateServer.ClientCert &&
-							s.ClientKey == candidateServer.ClientKey
+							s.ClientKey == candidateServer.ClientKey &&
+							s.ServerName == candidateServer.ServerName &&
+							s.Driver == candidateServer.Driver
 					})
 					if serverIdx == -1 {
 						serverIdx = len(cfg.SQLServers)
@@ -223,6 +229,11 @@ func (c *legacyConverter) Convert() (*config.Runtime, error) {
 						Password:       c.secretString(role.Password),
 						MinConnections: int(pool.MinConnections),
 						MaxConnections: int(pool.MaxConnections),
+						SearchPath:     db.SearchPath,
+						ReadOnly:       pool.IsReadonly,
+						WarmupOnStart:  pool.WarmupOnStart,
+						MaxLifetime:    pool.MaxLifetime.AsDuration(),
+						MaxIdleTime:    pool.MaxIdleTime.AsDuration(),
 					})
 				}
 			}
@@ -297,6 +308,7 @@ func (c *legacyConverter) Convert() (*config.Runtime, error) {
 					if primary.TlsConfig != nil {
 						candidateServer.EnableTLS = true
 						candidateServer.ServerCACert = primary.TlsConfig.GetServerCaCert()
+						candidateServer.ServerName = primary.TlsConfig.GetServerName()
 					}
 
 					serverIdx := slices.IndexFunc(cfg.RedisServers, func(s *config.RedisServer) bool {
@@ -314,6 +326,9 @@ func (c *legacyConverter) Convert() (*config.Runtime, error) {
 						MinConnections: int(pool.MinConnections),
 						MaxConnections: int(pool.MaxConnections),
 						KeyPrefix:      nilPtrToZero(db.KeyPrefix),
+						WarmupOnStart:  pool.WarmupOnStart,
+						MaxLifetime:    pool.MaxLifetime.AsDuration(),
+						MaxIdleTime:    pool.MaxIdleTime.AsDuration(),
 					})
 				}
 			}
@@ -335,6 +350,15 @@ func (c *legacyConverter) Convert() (*config.Runtime, error) {
 					p.NSQ = &config.NSQProvider{Host: prov.Nsq.Hosts[0]}
 				case *runtimev1.PubSubCluster_Azure:
 					p.Azure = &config.AzureServiceBusProvider{Namespace: prov.Azure.Namespace}
+				case *runtimev1.PubSubCluster_Kafka_:
+					p.Kafka = &config.KafkaPubsubProvider{Brokers: prov.Kafka.Brokers}
+					if sasl := prov.Kafka.Sasl; sasl != nil {
+						p.Kafka.SASL = &config.KafkaSASL{
+							Mechanism: sasl.Mechanism,
+							Username:  sasl.Username,
+							Password:  c.secretString(sasl.Password),
+						}
+					}
 				default:
 					c.setErrf("unknown pubsub provider type %T", prov)
 					continue
@@ -396,12 +420,25 @@ func (c *legacyConverter) Convert() (*config.Runtime, error) {
 						EncoreName:   sub.SubscriptionEncoreName,
 						ProviderName: sub.SubscriptionCloudName,
 						PushOnly:     sub.PushOnly,
+						DeadLetter: func() *config.PubsubDeadLetterPolicy {
+							if dl := sub.DeadLetter; dl != nil {
+								return &config.PubsubDeadLetterPolicy{
+									TopicName:           dl.TopicEncoreName,
+									MaxDeliveryAttempts: int(dl.MaxDeliveryAttempts),
+								}
+							}
+							return nil
+						}(),
 						GCP: func() *config.PubsubSubscriptionGCPData {
 							switch pc := sub.ProviderConfig.(type) {
 							case *runtimev1.PubSubSubscription_GcpConfig:
 								return &config.PubsubSubscriptionGCPData{
 									ProjectID:          pc.GcpConfig.ProjectId,
 									PushServiceAccount: pc.GcpConfig.GetPushServiceAccount(),
+									PushJWTAudience:    pc.GcpConfig.GetPushJwtAudience(),
+									PushEndpoint:       pc.GcpConfig.GetPushEndpoint(),
+									AckDeadline:        pc.GcpConfig.GetAckDeadline().AsDuration(),
+									MessageRetention:   pc.GcpConfig.GetMessageRetention().AsDuration(),
 								}
 							}
 							return nil
@@ -415,6 +452,9 @@ func (c *legacyConverter) Convert() (*config.Runtime, error) {
 		{
 			for _, cluster := range c.in.Infra.Resources.BucketClusters {
 				p := &config.BucketProvider{}
+				if ttl := cluster.DefaultSignedUrlTtl; ttl != nil {
+					p.DefaultSignedURLTTL = ptr(ttl.AsDuration())
+				}
 				switch prov := cluster.Provider.(type) {
 				case *runtimev1.BucketCluster_S3_:
 					p.S3 = &config.S3BucketProvider{
@@ -645,6 +685,10 @@ func (c *legacyConverter) secretBytes(s *runtimev1.SecretData) []byte {
 			c.setErrf("missing secret env var %q", data.Env)
 		}
 		secretData = val
+	case *runtimev1.SecretData_File:
+		// The legacy runtime config has no way to reference a file.
+		c.setErrf("secret file %q is not supported in the legacy runtime config", data.File)
+		return nil
 	default:
 		c.setErrf("unknown secret data type %T", data)
 		return nil
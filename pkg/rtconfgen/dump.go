@@ -0,0 +1,129 @@
+package rtconfgen
+
+import (
+	"encoding/json"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"sigs.k8s.io/yaml"
+
+	runtimev1 "encr.dev/proto/encore/runtime/v1"
+)
+
+// DumpFormat is a format to dump runtime configs in.
+type DumpFormat int
+
+const (
+	DumpJSON DumpFormat = iota
+	DumpYAML
+)
+
+// redactedValue replaces secret values in dumped runtime configs.
+const redactedValue = "***"
+
+// DumpRuntimeConfig renders conf in a human-readable format, for debugging.
+// Secret values embedded in the config are replaced with "***".
+func DumpRuntimeConfig(conf *runtimev1.RuntimeConfig, format DumpFormat) ([]byte, error) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(conf)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal runtime config")
+	}
+	var obj map[string]any
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, errors.Wrap(err, "unmarshal runtime config")
+	}
+	redactJSON(conf.ProtoReflect(), obj)
+
+	switch format {
+	case DumpJSON:
+		return json.MarshalIndent(obj, "", "  ")
+	case DumpYAML:
+		return yaml.Marshal(obj)
+	default:
+		return nil, errors.Newf("unknown dump format %d", format)
+	}
+}
+
+// redactJSON replaces the secret values in obj, the JSON representation of msg.
+func redactJSON(msg protoreflect.Message, obj map[string]any) {
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		key := string(fd.Name())
+		val, ok := obj[key]
+		if !ok {
+			return true
+		}
+		if isSecretField(fd) {
+			obj[key] = redactedValue
+			return true
+		}
+		if fd.Message() == nil || (fd.IsMap() && fd.MapValue().Message() == nil) {
+			return true
+		}
+
+		switch {
+		case fd.IsList():
+			list, _ := val.([]any)
+			for i := 0; i < v.List().Len() && i < len(list); i++ {
+				if sub, ok := list[i].(map[string]any); ok {
+					redactJSON(v.List().Get(i).Message(), sub)
+				}
+			}
+		case fd.IsMap():
+			entries, _ := val.(map[string]any)
+			v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+				if sub, ok := entries[k.String()].(map[string]any); ok {
+					redactJSON(mv.Message(), sub)
+				}
+				return true
+			})
+		default:
+			// Well-known types like durations aren't represented as objects.
+			if sub, ok := val.(map[string]any); ok {
+				redactJSON(v.Message(), sub)
+			}
+		}
+		return true
+	})
+}
+
+// RedactSecrets returns a copy of conf with all secret values embedded in it,
+// such as database passwords, app secrets and auth keys, replaced with "***".
+// Use it before logging a runtime config.
+func RedactSecrets(conf *runtimev1.RuntimeConfig) *runtimev1.RuntimeConfig {
+	conf = cloneProto(conf)
+	redactMessage(conf.ProtoReflect())
+	return conf
+}
+
+// redactMessage replaces the secret values in msg, in place.
+func redactMessage(msg protoreflect.Message) {
+	var secretFields []protoreflect.FieldDescriptor
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case isSecretField(fd):
+			secretFields = append(secretFields, fd)
+		case fd.IsList() && fd.Message() != nil:
+			for i := 0; i < v.List().Len(); i++ {
+				redactMessage(v.List().Get(i).Message())
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				redactMessage(mv.Message())
+				return true
+			})
+		case !fd.IsList() && !fd.IsMap() && fd.Message() != nil:
+			redactMessage(v.Message())
+		}
+		return true
+	})
+	for _, fd := range secretFields {
+		msg.Set(fd, protoreflect.ValueOfBytes([]byte(redactedValue)))
+	}
+}
+
+// isSecretField reports whether fd holds a secret value.
+func isSecretField(fd protoreflect.FieldDescriptor) bool {
+	return fd.ContainingMessage().FullName() == (&runtimev1.SecretData{}).ProtoReflect().Descriptor().FullName() &&
+		fd.Name() == "embedded"
+}
@@ -0,0 +1,97 @@
+package rtconfgen
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/protobuf/proto"
+
+	runtimev1 "encr.dev/proto/encore/runtime/v1"
+)
+
+func TestDumpRuntimeConfig(t *testing.T) {
+	c := qt.New(t)
+
+	embedded := func(s string) *runtimev1.SecretData {
+		return &runtimev1.SecretData{Source: &runtimev1.SecretData_Embedded{Embedded: []byte(s)}}
+	}
+
+	b := NewBuilder()
+	b.Infra.SQLRole(&runtimev1.SQLRole{Rid: "role", Username: "encore", Password: embedded("db-password")})
+	b.Infra.AppSecret(&runtimev1.AppSecret{Rid: "secret", EncoreName: "StripeKey", Data: embedded("stripe-key")})
+	b.Infra.AppSecret(&runtimev1.AppSecret{Rid: "env-secret", EncoreName: "FromEnv", Data: &runtimev1.SecretData{
+		Source: &runtimev1.SecretData_Env{Env: "FROM_ENV"},
+	}})
+	b.AuthMethods([]*runtimev1.ServiceAuth{{AuthMethod: &runtimev1.ServiceAuth_EncoreAuth_{
+		EncoreAuth: &runtimev1.ServiceAuth_EncoreAuth{AuthKeys: []*runtimev1.EncoreAuthKey{{Id: 1, Data: embedded("auth-key")}}},
+	}}})
+	conf, err := b.Deployment("deploy").ServiceDiscovery(&runtimev1.ServiceDiscovery{
+		Services: map[string]*runtimev1.ServiceDiscovery_Location{"foo": {
+			BaseUrl: "http://localhost:1234",
+			AuthMethods: []*runtimev1.ServiceAuth{{AuthMethod: &runtimev1.ServiceAuth_EncoreAuth_{
+				EncoreAuth: &runtimev1.ServiceAuth_EncoreAuth{AuthKeys: []*runtimev1.EncoreAuthKey{{Id: 1, Data: embedded("auth-key")}}},
+			}}},
+		}},
+	}).BuildRuntimeConfig()
+	c.Assert(err, qt.IsNil)
+
+	for _, format := range []DumpFormat{DumpJSON, DumpYAML} {
+		out, err := DumpRuntimeConfig(conf, format)
+		c.Assert(err, qt.IsNil)
+		dump := string(out)
+
+		for _, secret := range []string{"db-password", "stripe-key", "auth-key"} {
+			c.Assert(dump, qt.Not(qt.Contains), secret)
+		}
+		c.Assert(strings.Count(dump, "***"), qt.Equals, 4)
+		c.Assert(dump, qt.Contains, "FROM_ENV")
+		c.Assert(dump, qt.Contains, "http://localhost:1234")
+	}
+
+	out, err := DumpRuntimeConfig(conf, DumpJSON)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(out), qt.Contains, `"embedded": "***"`)
+}
+
+func TestRedactSecrets(t *testing.T) {
+	c := qt.New(t)
+
+	embedded := func(s string) *runtimev1.SecretData {
+		return &runtimev1.SecretData{Source: &runtimev1.SecretData_Embedded{Embedded: []byte(s)}}
+	}
+	secrets := []string{"sql-password", "redis-password", "app-secret", "auth-key", "sd-auth-key"}
+
+	b := NewBuilder()
+	b.Infra.SQLRole(&runtimev1.SQLRole{Rid: "sql-role", Username: "encore", Password: embedded("sql-password")})
+	b.Infra.RedisRole(&runtimev1.RedisRole{Rid: "redis-role", Auth: &runtimev1.RedisRole_AuthString{AuthString: embedded("redis-password")}})
+	b.Infra.AppSecret(&runtimev1.AppSecret{Rid: "secret", EncoreName: "StripeKey", Data: embedded("app-secret")})
+	b.AuthMethods([]*runtimev1.ServiceAuth{{AuthMethod: &runtimev1.ServiceAuth_EncoreAuth_{
+		EncoreAuth: &runtimev1.ServiceAuth_EncoreAuth{AuthKeys: []*runtimev1.EncoreAuthKey{{Id: 1, Data: embedded("auth-key")}}},
+	}}})
+	conf, err := b.Deployment("deploy").ServiceDiscovery(&runtimev1.ServiceDiscovery{
+		Services: map[string]*runtimev1.ServiceDiscovery_Location{"foo": {
+			AuthMethods: []*runtimev1.ServiceAuth{{AuthMethod: &runtimev1.ServiceAuth_EncoreAuth_{
+				EncoreAuth: &runtimev1.ServiceAuth_EncoreAuth{AuthKeys: []*runtimev1.EncoreAuthKey{{Id: 1, Data: embedded("sd-auth-key")}}},
+			}}},
+		}},
+	}).BuildRuntimeConfig()
+	c.Assert(err, qt.IsNil)
+
+	redacted := RedactSecrets(conf)
+	data, err := proto.Marshal(redacted)
+	c.Assert(err, qt.IsNil)
+	for _, secret := range secrets {
+		c.Assert(bytes.Contains(data, []byte(secret)), qt.IsFalse, qt.Commentf("secret %q", secret))
+	}
+	c.Assert(redacted.Infra.Credentials.SqlRoles[0].Password.GetEmbedded(), qt.DeepEquals, []byte("***"))
+	c.Assert(redacted.Infra.Credentials.SqlRoles[0].Username, qt.Equals, "encore")
+
+	// The original config is left as-is.
+	data, err = proto.Marshal(conf)
+	c.Assert(err, qt.IsNil)
+	for _, secret := range secrets {
+		c.Assert(bytes.Contains(data, []byte(secret)), qt.IsTrue, qt.Commentf("secret %q", secret))
+	}
+}
@@ -1,6 +1,8 @@
 package rtconfgen
 
 import (
+	"cmp"
+	"maps"
 	"slices"
 
 	meta "encr.dev/proto/encore/parser/meta/v1"
@@ -260,8 +262,8 @@ func tofn[V any](v V) func() V {
 }
 
 // reduceForServices reduces the given infrastructure to only include resource accessible by
-// the given services, using the metadata for access control.
-func reduceForServices(infra *runtimev1.Infrastructure, md *meta.Data, svcs []string) *runtimev1.Infrastructure {
+// the given services and gateways, using the metadata for access control.
+func reduceForServices(infra *runtimev1.Infrastructure, md *meta.Data, svcs, gateways []string) *runtimev1.Infrastructure {
 	// Clone the protobuf so the changes don't affect the original.
 	infra = cloneProto(infra)
 
@@ -270,87 +272,182 @@ func reduceForServices(infra *runtimev1.Infrastructure, md *meta.Data, svcs []st
 		svcNames[svc] = true
 	}
 
-	dbsToKeep := make(map[string]bool)
-	for _, svc := range md.Svcs {
-		if !svcNames[svc.Name] {
-			continue
+	// Gateways need access to the buckets of the service they belong to,
+	// since that's where the auth handler is defined (e.g. for generating signed URLs).
+	bucketSvcNames := maps.Clone(svcNames)
+	for _, gw := range md.Gateways {
+		if gw.Explicit != nil && slices.Contains(gateways, gw.EncoreName) {
+			bucketSvcNames[gw.Explicit.ServiceName] = true
 		}
-		for _, dbName := range svc.Databases {
-			dbsToKeep[dbName] = true
+	}
+
+	used := findUsedResources(md, svcNames, bucketSvcNames)
+
+	for _, cluster := range infra.Resources.PubsubClusters {
+		cluster.Topics = slices.DeleteFunc(cluster.Topics, func(t *runtimev1.PubSubTopic) bool {
+			_, found := used.topics[t.EncoreName]
+			return !found
+		})
+		cluster.Subscriptions = slices.DeleteFunc(cluster.Subscriptions, func(t *runtimev1.PubSubSubscription) bool {
+			_, found := used.subs[Subscription{Topic: t.TopicEncoreName, Name: t.SubscriptionEncoreName}]
+			return !found
+		})
+	}
+
+	for _, cluster := range infra.Resources.RedisClusters {
+		cluster.Databases = slices.DeleteFunc(cluster.Databases, func(t *runtimev1.RedisDatabase) bool {
+			_, found := used.caches[t.EncoreName]
+			return !found
+		})
+	}
+
+	for _, cluster := range infra.Resources.BucketClusters {
+		cluster.Buckets = slices.DeleteFunc(cluster.Buckets, func(t *runtimev1.Bucket) bool {
+			_, found := used.buckets[t.EncoreName]
+			return !found
+		})
+		for _, bkt := range cluster.Buckets {
+			if used.writableBuckets[bkt.EncoreName] {
+				bkt.AccessMode = runtimev1.Bucket_ACCESS_MODE_READ_WRITE
+			} else {
+				bkt.AccessMode = runtimev1.Bucket_ACCESS_MODE_READ_ONLY
+			}
 		}
 	}
 
-	bucketsToKeep := make(map[string]bool)
+	infra.Resources.AppSecrets = slices.DeleteFunc(infra.Resources.AppSecrets, func(t *runtimev1.AppSecret) bool {
+		_, found := used.secrets[t.EncoreName]
+		return !found
+	})
+
+	return infra
+}
+
+// ResourceSet is a set of resources used by one or more services,
+// identified by their Encore names. Each list is sorted.
+type ResourceSet struct {
+	Databases     []string
+	Topics        []string // topics published to
+	Subscriptions []Subscription
+	Caches        []string
+	Buckets       []string
+	Secrets       []string
+}
+
+// Subscription identifies a Pub/Sub subscription.
+type Subscription struct {
+	Topic string
+	Name  string
+}
+
+// ResourcesUsedBy returns the resources used by the given services,
+// using the same rules as when reducing a deployment's infrastructure.
+func ResourcesUsedBy(md *meta.Data, svcs ...string) ResourceSet {
+	svcNames := make(map[string]bool)
+	for _, svc := range svcs {
+		svcNames[svc] = true
+	}
+	used := findUsedResources(md, svcNames, svcNames)
+
+	subs := slices.Collect(maps.Keys(used.subs))
+	slices.SortFunc(subs, func(a, b Subscription) int {
+		return cmp.Or(cmp.Compare(a.Topic, b.Topic), cmp.Compare(a.Name, b.Name))
+	})
+	return ResourceSet{
+		Databases:     slices.Sorted(maps.Keys(used.dbs)),
+		Topics:        slices.Sorted(maps.Keys(used.topics)),
+		Subscriptions: subs,
+		Caches:        slices.Sorted(maps.Keys(used.caches)),
+		Buckets:       slices.Sorted(maps.Keys(used.buckets)),
+		Secrets:       slices.Sorted(maps.Keys(used.secrets)),
+	}
+}
+
+// usedResources is the set of resources used by a set of services.
+type usedResources struct {
+	dbs     map[string]bool
+	topics  map[string]bool
+	subs    map[Subscription]bool
+	caches  map[string]bool
+	buckets map[string]bool
+	secrets map[string]bool
+
+	// writableBuckets are the buckets that are written to.
+	writableBuckets map[string]bool
+}
+
+// findUsedResources returns the resources used by svcNames, using the metadata for access control.
+// Buckets are instead those used by bucketSvcNames.
+func findUsedResources(md *meta.Data, svcNames, bucketSvcNames map[string]bool) usedResources {
+	used := usedResources{
+		dbs:     make(map[string]bool),
+		topics:  make(map[string]bool),
+		subs:    make(map[Subscription]bool),
+		caches:  make(map[string]bool),
+		buckets: make(map[string]bool),
+
+		writableBuckets: make(map[string]bool),
+	}
+
 	for _, svc := range md.Svcs {
-		if !svcNames[svc.Name] {
-			continue
+		if svcNames[svc.Name] {
+			for _, dbName := range svc.Databases {
+				used.dbs[dbName] = true
+			}
 		}
-		for _, bktName := range svc.Buckets {
-			bucketsToKeep[bktName.Bucket] = true
+		if bucketSvcNames[svc.Name] {
+			for _, bktName := range svc.Buckets {
+				used.buckets[bktName.Bucket] = true
+				if writesToBucket(bktName) {
+					used.writableBuckets[bktName.Bucket] = true
+				}
+			}
 		}
 	}
 
-	type subKey struct {
-		topicName string
-		subName   string
-	}
-	topicsToKeep := make(map[string]bool)
-	subsToKeep := make(map[subKey]bool)
 	for _, topic := range md.PubsubTopics {
 		for _, publisher := range topic.Publishers {
 			if svcNames[publisher.ServiceName] {
-				topicsToKeep[topic.Name] = true
+				used.topics[topic.Name] = true
 			}
 		}
 
 		for _, subscriber := range topic.Subscriptions {
 			if svcNames[subscriber.ServiceName] {
-				subsToKeep[subKey{topicName: topic.Name, subName: subscriber.Name}] = true
+				used.subs[Subscription{Topic: topic.Name, Name: subscriber.Name}] = true
 			}
 		}
 	}
 
-	cachesToKeep := make(map[string]bool)
 	for _, cacheCluster := range md.CacheClusters {
 		for _, keySpace := range cacheCluster.Keyspaces {
 			if svcNames[keySpace.Service] {
-				cachesToKeep[cacheCluster.Name] = true
+				used.caches[cacheCluster.Name] = true
 			}
 		}
 	}
 
-	for _, cluster := range infra.Resources.PubsubClusters {
-		cluster.Topics = slices.DeleteFunc(cluster.Topics, func(t *runtimev1.PubSubTopic) bool {
-			_, found := topicsToKeep[t.EncoreName]
-			return !found
-		})
-		cluster.Subscriptions = slices.DeleteFunc(cluster.Subscriptions, func(t *runtimev1.PubSubSubscription) bool {
-			_, found := subsToKeep[subKey{topicName: t.TopicEncoreName, subName: t.SubscriptionEncoreName}]
-			return !found
-		})
-	}
+	used.secrets = secretsUsedByServices(md, svcNames)
+	return used
+}
 
-	for _, cluster := range infra.Resources.RedisClusters {
-		cluster.Databases = slices.DeleteFunc(cluster.Databases, func(t *runtimev1.RedisDatabase) bool {
-			_, found := cachesToKeep[t.EncoreName]
-			return !found
-		})
+// writesToBucket reports whether the bucket usage includes writing to it.
+// Usages without known operations are assumed to write.
+func writesToBucket(usage *meta.BucketUsage) bool {
+	if len(usage.Operations) == 0 {
+		return true
 	}
-
-	for _, cluster := range infra.Resources.BucketClusters {
-		cluster.Buckets = slices.DeleteFunc(cluster.Buckets, func(t *runtimev1.Bucket) bool {
-			_, found := bucketsToKeep[t.EncoreName]
-			return !found
-		})
+	for _, op := range usage.Operations {
+		switch op {
+		case meta.BucketUsage_LIST_OBJECTS, meta.BucketUsage_READ_OBJECT_CONTENTS,
+			meta.BucketUsage_GET_OBJECT_METADATA, meta.BucketUsage_GET_PUBLIC_URL,
+			meta.BucketUsage_SIGNED_DOWNLOAD_URL:
+			continue
+		default:
+			return true
+		}
 	}
-
-	secretsToKeep := secretsUsedByServices(md, svcNames)
-	infra.Resources.AppSecrets = slices.DeleteFunc(infra.Resources.AppSecrets, func(t *runtimev1.AppSecret) bool {
-		_, found := secretsToKeep[t.EncoreName]
-		return !found
-	})
-
-	return infra
+	return false
 }
 
 // secretsUsedByServices returns the set of secrets that are accessible by the given services, using the metadata for access control.
@@ -0,0 +1,72 @@
+package svcproxy
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Protocol is the protocol a process serves requests with,
+// which the proxy uses when forwarding requests to it.
+type Protocol int
+
+const (
+	// HTTP1 is plain HTTP/1.1. It's the default.
+	HTTP1 Protocol = iota
+	// H2C is HTTP/2 without TLS, as used by gRPC servers.
+	H2C
+	// HTTP2TLS is HTTP/2 over TLS.
+	HTTP2TLS
+)
+
+func (p Protocol) String() string {
+	switch p {
+	case HTTP1:
+		return "http/1.1"
+	case H2C:
+		return "h2c"
+	case HTTP2TLS:
+		return "h2"
+	default:
+		return "unknown"
+	}
+}
+
+// scheme is the URL scheme to use when talking to a process serving p.
+func (p Protocol) scheme() string {
+	if p == HTTP2TLS {
+		return "https"
+	}
+	return "http"
+}
+
+// newTransport returns a transport for talking to a process serving p.
+func newTransport(p Protocol) *http.Transport {
+	// This transport is copied from the default transport in the http package just with the dial context
+	// wrapped in our retry dialer.
+	t := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&retryDialer{net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+
+	switch p {
+	case H2C:
+		t.Protocols = new(http.Protocols)
+		t.Protocols.SetUnencryptedHTTP2(true)
+	case HTTP2TLS:
+		t.Protocols = new(http.Protocols)
+		t.Protocols.SetHTTP2(true)
+		// Processes run locally with self-signed certificates.
+		t.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	return t
+}
@@ -9,6 +9,7 @@ import (
 	"net/netip"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -22,9 +23,28 @@ type SvcProxy struct {
 	logger     zerolog.Logger
 	httpServer *http.Server
 
-	mu       sync.RWMutex
-	gateways map[string]*httputil.ReverseProxy // Map of the gateway name to address and port it's listening on
-	services map[string]*httputil.ReverseProxy // Map of service name to address and port it's listening on
+	mu           sync.RWMutex
+	gateways     map[string]*httputil.ReverseProxy // Map of the gateway name to address and port it's listening on
+	services     map[string]*httputil.ReverseProxy // Map of service name to address and port it's listening on
+	serviceAddrs map[string]netip.AddrPort         // Map of service name to address and port it's listening on
+	serviceProto map[string]Protocol               // Map of service name to the protocol it serves
+	healthChecks map[string]*healthCheck           // Map of service name to its health check, if any
+
+	// The TLS listener for services registered with TLS, started on first use.
+	tlsListener net.Listener
+	tlsServer   *http.Server
+	tlsCertPEM  string
+}
+
+// healthCheckTimeout is how long requests are held waiting for a service to become ready
+// before they are forwarded anyway. It's a variable so tests can shorten it.
+var healthCheckTimeout = 30 * time.Second
+
+// healthCheck tracks whether a service has become ready to receive requests.
+type healthCheck struct {
+	url    string
+	client *http.Client
+	ready  atomic.Bool
 }
 
 var (
@@ -42,16 +62,26 @@ func New(ctx context.Context, logger zerolog.Logger) (*SvcProxy, error) {
 	}
 
 	proxy := &SvcProxy{
-		listener: ln,
-		logger:   logger,
-		gateways: make(map[string]*httputil.ReverseProxy),
-		services: make(map[string]*httputil.ReverseProxy),
+		listener:     ln,
+		logger:       logger,
+		gateways:     make(map[string]*httputil.ReverseProxy),
+		services:     make(map[string]*httputil.ReverseProxy),
+		serviceAddrs: make(map[string]netip.AddrPort),
+		serviceProto: make(map[string]Protocol),
+		healthChecks: make(map[string]*healthCheck),
 	}
 
+	// Accept h2c as well so HTTP/2 clients, like gRPC clients,
+	// can talk to services serving H2C.
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+
 	proxy.httpServer = &http.Server{
 		Addr:        ln.Addr().String(),
 		BaseContext: func(_ net.Listener) context.Context { return ctx },
 		Handler:     proxy,
+		Protocols:   protocols,
 	}
 
 	go func() {
@@ -67,46 +97,104 @@ func New(ctx context.Context, logger zerolog.Logger) (*SvcProxy, error) {
 func (p *SvcProxy) Close() {
 	_ = p.httpServer.Close()
 	_ = p.listener.Close()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.tlsServer != nil {
+		_ = p.tlsServer.Close()
+		_ = p.tlsListener.Close()
+	}
 }
 
+// RegisterGateway registers a gateway with the proxy and returns the BaseURL to be used
+// to access the gateway.
 func (p *SvcProxy) RegisterGateway(name string, addr netip.AddrPort) string {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	p.gateways[name] = p.createReverseProxy("gateway", name, addr)
+	p.gateways[name] = p.createReverseProxy("gateway", name, addr, HTTP1)
 
 	return fmt.Sprintf("http://%s/gateway/%s", p.listener.Addr().String(), name)
 }
 
 // RegisterService registers a service with the proxy and returns the BaseURL to be used
-// to access the service.
-func (p *SvcProxy) RegisterService(name string, addr netip.AddrPort) string {
+// to access the service. Requests are forwarded to the service using proto.
+func (p *SvcProxy) RegisterService(name string, addr netip.AddrPort, proto Protocol) string {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	p.services[name] = p.createReverseProxy("service", name, addr)
+	p.services[name] = p.createReverseProxy("service", name, addr, proto)
+	p.serviceAddrs[name] = addr
+	p.serviceProto[name] = proto
+	delete(p.healthChecks, name)
 
 	return fmt.Sprintf("http://%s/service/%s", p.listener.Addr().String(), name)
 }
 
-func (p *SvcProxy) createReverseProxy(what, name string, listener netip.AddrPort) *httputil.ReverseProxy {
+// RegisterServiceTLS is like RegisterService, but the returned BaseURL
+// is an https URL where the proxy terminates TLS using a self-signed certificate
+// for localhost. See TLSCert for the certificate.
+func (p *SvcProxy) RegisterServiceTLS(name string, addr netip.AddrPort, proto Protocol) (string, error) {
+	p.RegisterService(name, addr, proto)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.startTLS(); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("https://%s/service/%s", p.tlsListener.Addr().String(), name), nil
+}
+
+// SetHealthCheck makes the proxy hold requests to the given service until
+// it responds successfully to requests on path, or healthCheckTimeout passes.
+// The service must already be registered.
+func (p *SvcProxy) SetHealthCheck(name, path string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	addr, ok := p.serviceAddrs[name]
+	if !ok {
+		return
+	}
+	proto := p.serviceProto[name]
+	p.healthChecks[name] = &healthCheck{
+		url:    fmt.Sprintf("%s://%s%s", proto.scheme(), addr, path),
+		client: &http.Client{Transport: p.services[name].Transport, Timeout: time.Second},
+	}
+}
+
+// waitReady waits for the service to pass its health check.
+// It returns early if ctx is canceled or healthCheckTimeout passes.
+func (p *SvcProxy) waitReady(ctx context.Context, hc *healthCheck) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	for !hc.ready.Load() {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, hc.url, nil)
+		if err != nil {
+			return
+		}
+		if resp, err := hc.client.Do(req); err == nil {
+			_ = resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				hc.ready.Store(true)
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+func (p *SvcProxy) createReverseProxy(what, name string, listener netip.AddrPort, proto Protocol) *httputil.ReverseProxy {
 	return &httputil.ReverseProxy{
-		// This transport is copied from the default transport in the http package just with the dial context
-		// wrapped in our retry dialer.
-		Transport: &http.Transport{
-			Proxy: http.ProxyFromEnvironment,
-			DialContext: (&retryDialer{net.Dialer{
-				Timeout:   30 * time.Second,
-				KeepAlive: 30 * time.Second,
-			}}).DialContext,
-			ForceAttemptHTTP2:     true,
-			MaxIdleConns:          100,
-			IdleConnTimeout:       90 * time.Second,
-			TLSHandshakeTimeout:   10 * time.Second,
-			ExpectContinueTimeout: 1 * time.Second,
-		},
+		Transport: newTransport(proto),
 		Rewrite: func(request *httputil.ProxyRequest) {
-			request.Out.URL.Scheme = "http"
+			request.Out.URL.Scheme = proto.scheme()
 			request.Out.URL.Host = listener.String()
 			request.Out.URL.Path = strings.TrimPrefix(request.In.URL.Path, fmt.Sprintf("/%s/%s", what, name))
 		},
@@ -117,6 +205,7 @@ func (p *SvcProxy) createReverseProxy(what, name string, listener netip.AddrPort
 // ServeHTTP implements the http.Handler interface
 func (p *SvcProxy) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	// Resolve the handler with the lock held.
+	var hc *healthCheck
 	proxy, err := func() (http.Handler, error) {
 		p.mu.RLock()
 		defer p.mu.RUnlock()
@@ -136,6 +225,7 @@ func (p *SvcProxy) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 				if !ok {
 					return nil, errors.Newf("unknown service: %s", parts[1])
 				} else {
+					hc = p.healthChecks[parts[1]]
 					return proxy, nil
 				}
 			default:
@@ -152,5 +242,9 @@ func (p *SvcProxy) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if hc != nil && !hc.ready.Load() {
+		p.waitReady(req.Context(), hc)
+	}
+
 	proxy.ServeHTTP(w, req)
 }
@@ -0,0 +1,138 @@
+package svcproxy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/rs/zerolog"
+)
+
+// newTestService starts a service that responds "hello" to requests,
+// and whose /healthz endpoint succeeds once ready is set.
+func newTestService(c *qt.C) (addr netip.AddrPort, ready *atomic.Bool) {
+	ready = new(atomic.Bool)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/healthz" {
+			if !ready.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+			return
+		}
+		_, _ = io.WriteString(w, "hello")
+	}))
+	c.Cleanup(srv.Close)
+	return netip.MustParseAddrPort(srv.Listener.Addr().String()), ready
+}
+
+func newTestProxy(c *qt.C) *SvcProxy {
+	proxy, err := New(context.Background(), zerolog.Nop())
+	c.Assert(err, qt.IsNil)
+	c.Cleanup(proxy.Close)
+	return proxy
+}
+
+// get makes a request to url and returns the response body.
+func get(c *qt.C, client *http.Client, url string) string {
+	resp, err := client.Get(url)
+	c.Assert(err, qt.IsNil)
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, qt.Equals, http.StatusOK)
+	body, err := io.ReadAll(resp.Body)
+	c.Assert(err, qt.IsNil)
+	return string(body)
+}
+
+func TestSetHealthCheck_HoldsUntilReady(t *testing.T) {
+	c := qt.New(t)
+	proxy := newTestProxy(c)
+	addr, ready := newTestService(c)
+
+	baseURL := proxy.RegisterService("foo", addr, HTTP1)
+	proxy.SetHealthCheck("foo", "/healthz")
+
+	done := make(chan error, 1)
+	go func() {
+		resp, err := http.Get(baseURL + "/hello")
+		if err == nil {
+			_ = resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				err = fmt.Errorf("unexpected status %s", resp.Status)
+			}
+		}
+		done <- err
+	}()
+
+	// The request is held while the service isn't ready.
+	select {
+	case <-done:
+		c.Fatal("request forwarded before the service was ready")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	ready.Store(true)
+	select {
+	case err := <-done:
+		c.Assert(err, qt.IsNil)
+	case <-time.After(5 * time.Second):
+		c.Fatal("request not forwarded once the service was ready")
+	}
+
+	// Once ready, requests are forwarded without probing again.
+	ready.Store(false)
+	c.Assert(get(c, http.DefaultClient, baseURL+"/hello"), qt.Equals, "hello")
+}
+
+func TestSetHealthCheck_Timeout(t *testing.T) {
+	c := qt.New(t)
+	prev := healthCheckTimeout
+	healthCheckTimeout = 300 * time.Millisecond
+	c.Cleanup(func() { healthCheckTimeout = prev })
+
+	proxy := newTestProxy(c)
+	addr, _ := newTestService(c)
+	baseURL := proxy.RegisterService("foo", addr, HTTP1)
+	proxy.SetHealthCheck("foo", "/healthz")
+
+	// The service never becomes ready, so the request is forwarded after the timeout.
+	start := time.Now()
+	c.Assert(get(c, http.DefaultClient, baseURL+"/hello"), qt.Equals, "hello")
+	c.Assert(time.Since(start) >= healthCheckTimeout, qt.IsTrue)
+}
+
+func TestRegisterService_ClearsHealthCheck(t *testing.T) {
+	c := qt.New(t)
+	proxy := newTestProxy(c)
+	addr, _ := newTestService(c)
+
+	proxy.RegisterService("foo", addr, HTTP1)
+	proxy.SetHealthCheck("foo", "/healthz")
+	baseURL := proxy.RegisterService("foo", addr, HTTP1)
+
+	// The service never becomes ready, but the re-registration dropped the health check.
+	start := time.Now()
+	c.Assert(get(c, http.DefaultClient, baseURL+"/hello"), qt.Equals, "hello")
+	c.Assert(time.Since(start) < healthCheckTimeout, qt.IsTrue)
+
+	proxy.mu.RLock()
+	defer proxy.mu.RUnlock()
+	c.Assert(proxy.healthChecks, qt.HasLen, 0)
+}
+
+func TestSetHealthCheck_UnknownService(t *testing.T) {
+	c := qt.New(t)
+	proxy := newTestProxy(c)
+
+	proxy.SetHealthCheck("foo", "/healthz")
+
+	proxy.mu.RLock()
+	defer proxy.mu.RUnlock()
+	c.Assert(proxy.healthChecks, qt.HasLen, 0)
+}
@@ -0,0 +1,91 @@
+package svcproxy
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// startTLS starts serving the proxy over TLS with a self-signed certificate
+// for localhost, if it isn't already. It must be called with p.mu held.
+func (p *SvcProxy) startTLS() error {
+	if p.tlsListener != nil {
+		return nil
+	}
+
+	cert, certPEM, err := localCert()
+	if err != nil {
+		return errors.Wrap(err, "unable to generate tls certificate")
+	}
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   []string{"h2", "http/1.1"},
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to listen")
+	}
+
+	p.tlsListener = ln
+	p.tlsCertPEM = certPEM
+	p.tlsServer = &http.Server{
+		Addr:        ln.Addr().String(),
+		BaseContext: p.httpServer.BaseContext,
+		Handler:     p,
+	}
+	go func() {
+		if err := p.tlsServer.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			p.logger.Err(err).Msg("error serving tls")
+		}
+	}()
+	return nil
+}
+
+// TLSCert returns the PEM-encoded certificate the proxy presents
+// to clients of services registered with TLS, or "" if there are none.
+func (p *SvcProxy) TLSCert() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.tlsCertPEM
+}
+
+// localCert generates a self-signed certificate for localhost.
+func localCert() (cert tls.Certificate, certPEM string, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, "", err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, "", err
+	}
+
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "encore local service proxy"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(365 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, "", err
+	}
+
+	cert = tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	certPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	return cert, certPEM, nil
+}
@@ -0,0 +1,50 @@
+package svcproxy
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestRegisterServiceTLS(t *testing.T) {
+	c := qt.New(t)
+	proxy := newTestProxy(c)
+	addr, _ := newTestService(c)
+
+	// The TLS listener is only started once a service needs it.
+	proxy.RegisterService("plain", addr, HTTP1)
+	c.Assert(proxy.TLSCert(), qt.Equals, "")
+	c.Assert(proxy.tlsListener, qt.IsNil)
+
+	fooURL, err := proxy.RegisterServiceTLS("foo", addr, HTTP1)
+	c.Assert(err, qt.IsNil)
+	barURL, err := proxy.RegisterServiceTLS("bar", addr, HTTP1)
+	c.Assert(err, qt.IsNil)
+
+	// Both services share the listener.
+	foo, err := url.Parse(fooURL)
+	c.Assert(err, qt.IsNil)
+	bar, err := url.Parse(barURL)
+	c.Assert(err, qt.IsNil)
+	c.Assert(foo.Scheme, qt.Equals, "https")
+	c.Assert(foo.Host, qt.Equals, bar.Host)
+	c.Assert(foo.Path, qt.Equals, "/service/foo")
+
+	// Clients trusting the proxy's certificate can reach the services.
+	certPEM := proxy.TLSCert()
+	c.Assert(certPEM, qt.Not(qt.Equals), "")
+	roots := x509.NewCertPool()
+	c.Assert(roots.AppendCertsFromPEM([]byte(certPEM)), qt.IsTrue)
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}}
+	c.Assert(get(c, client, fooURL+"/hello"), qt.Equals, "hello")
+	c.Assert(get(c, client, barURL+"/hello"), qt.Equals, "hello")
+
+	// The certificate is stable across registrations.
+	_, err = proxy.RegisterServiceTLS("baz", addr, HTTP1)
+	c.Assert(err, qt.IsNil)
+	c.Assert(proxy.TLSCert(), qt.Equals, certPEM)
+}
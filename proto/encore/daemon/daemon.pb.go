@@ -3223,8 +3223,22 @@ type DumpMetaRequest struct {
 	// Each entry is a string in the format "KEY=VALUE", identical to os.Environ().
 	Environ []string `protobuf:"bytes,3,rep,name=environ,proto3" json:"environ,omitempty"`
 	// Whether or not to parse tests.
-	ParseTests    bool                   `protobuf:"varint,4,opt,name=parse_tests,json=parseTests,proto3" json:"parse_tests,omitempty"`
-	Format        DumpMetaRequest_Format `protobuf:"varint,5,opt,name=format,proto3,enum=encore.daemon.DumpMetaRequest_Format" json:"format,omitempty"`
+	ParseTests bool                   `protobuf:"varint,4,opt,name=parse_tests,json=parseTests,proto3" json:"parse_tests,omitempty"`
+	Format     DumpMetaRequest_Format `protobuf:"varint,5,opt,name=format,proto3,enum=encore.daemon.DumpMetaRequest_Format" json:"format,omitempty"`
+	// If set, only include the metadata for the given service
+	// and the resources it uses.
+	Service *string `protobuf:"bytes,6,opt,name=service,proto3,oneof" json:"service,omitempty"`
+	// If true, JSON output is not indented.
+	Compact bool `protobuf:"varint,7,opt,name=compact,proto3" json:"compact,omitempty"`
+	// If true, JSON output uses camelCase field names
+	// instead of the original snake_case names.
+	CamelCase bool `protobuf:"varint,8,opt,name=camel_case,json=camelCase,proto3" json:"camel_case,omitempty"`
+	// If true, check that all secrets used by the app are defined,
+	// reporting any missing ones in the response.
+	CheckSecrets bool `protobuf:"varint,9,opt,name=check_secrets,json=checkSecrets,proto3" json:"check_secrets,omitempty"`
+	// If true, fail if any secrets used by the app are not defined.
+	// Implies check_secrets.
+	StrictSecrets bool `protobuf:"varint,10,opt,name=strict_secrets,json=strictSecrets,proto3" json:"strict_secrets,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -3294,11 +3308,49 @@ func (x *DumpMetaRequest) GetFormat() DumpMetaRequest_Format {
 	return DumpMetaRequest_FORMAT_UNSPECIFIED
 }
 
+func (x *DumpMetaRequest) GetService() string {
+	if x != nil && x.Service != nil {
+		return *x.Service
+	}
+	return ""
+}
+
+func (x *DumpMetaRequest) GetCompact() bool {
+	if x != nil {
+		return x.Compact
+	}
+	return false
+}
+
+func (x *DumpMetaRequest) GetCamelCase() bool {
+	if x != nil {
+		return x.CamelCase
+	}
+	return false
+}
+
+func (x *DumpMetaRequest) GetCheckSecrets() bool {
+	if x != nil {
+		return x.CheckSecrets
+	}
+	return false
+}
+
+func (x *DumpMetaRequest) GetStrictSecrets() bool {
+	if x != nil {
+		return x.StrictSecrets
+	}
+	return false
+}
+
 type DumpMetaResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Meta          []byte                 `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Meta  []byte                 `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	// The secrets used by the app that are not defined.
+	// Only set if check_secrets is true.
+	MissingSecrets []string `protobuf:"bytes,2,rep,name=missing_secrets,json=missingSecrets,proto3" json:"missing_secrets,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *DumpMetaResponse) Reset() {
@@ -3338,6 +3390,120 @@ func (x *DumpMetaResponse) GetMeta() []byte {
 	return nil
 }
 
+func (x *DumpMetaResponse) GetMissingSecrets() []string {
+	if x != nil {
+		return x.MissingSecrets
+	}
+	return nil
+}
+
+// ParseErrors describes the errors encountered when parsing an app.
+// It is attached as a detail to DumpMeta errors.
+type ParseErrors struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Errors        []*ParseError          `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParseErrors) Reset() {
+	*x = ParseErrors{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParseErrors) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseErrors) ProtoMessage() {}
+
+func (x *ParseErrors) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseErrors.ProtoReflect.Descriptor instead.
+func (*ParseErrors) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ParseErrors) GetErrors() []*ParseError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type ParseError struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Title   string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Summary string                 `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
+	// The source locations that caused the error.
+	Locations     []*ParseError_Location `protobuf:"bytes,3,rep,name=locations,proto3" json:"locations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParseError) Reset() {
+	*x = ParseError{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParseError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseError) ProtoMessage() {}
+
+func (x *ParseError) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseError.ProtoReflect.Descriptor instead.
+func (*ParseError) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ParseError) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ParseError) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *ParseError) GetLocations() []*ParseError_Location {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
 // The following messages are used for sqlc plugin integration.
 type SQLCPlugin struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -3347,7 +3513,7 @@ type SQLCPlugin struct {
 
 func (x *SQLCPlugin) Reset() {
 	*x = SQLCPlugin{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[43]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3359,7 +3525,7 @@ func (x *SQLCPlugin) String() string {
 func (*SQLCPlugin) ProtoMessage() {}
 
 func (x *SQLCPlugin) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[43]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3372,7 +3538,91 @@ func (x *SQLCPlugin) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{45}
+}
+
+type ParseError_Location struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          string                 `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"` // relative to the app root, if known
+	Line          int32                  `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`
+	Column        int32                  `protobuf:"varint,3,opt,name=column,proto3" json:"column,omitempty"`
+	EndLine       int32                  `protobuf:"varint,4,opt,name=end_line,json=endLine,proto3" json:"end_line,omitempty"`
+	EndColumn     int32                  `protobuf:"varint,5,opt,name=end_column,json=endColumn,proto3" json:"end_column,omitempty"`
+	Text          string                 `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"` // optional text describing the location
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParseError_Location) Reset() {
+	*x = ParseError_Location{}
+	mi := &file_encore_daemon_daemon_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParseError_Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseError_Location) ProtoMessage() {}
+
+func (x *ParseError_Location) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_daemon_daemon_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseError_Location.ProtoReflect.Descriptor instead.
+func (*ParseError_Location) Descriptor() ([]byte, []int) {
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{44, 0}
+}
+
+func (x *ParseError_Location) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *ParseError_Location) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ParseError_Location) GetColumn() int32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *ParseError_Location) GetEndLine() int32 {
+	if x != nil {
+		return x.EndLine
+	}
+	return 0
+}
+
+func (x *ParseError_Location) GetEndColumn() int32 {
+	if x != nil {
+		return x.EndColumn
+	}
+	return 0
+}
+
+func (x *ParseError_Location) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
 }
 
 type SQLCPlugin_File struct {
@@ -3385,7 +3635,7 @@ type SQLCPlugin_File struct {
 
 func (x *SQLCPlugin_File) Reset() {
 	*x = SQLCPlugin_File{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[44]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3397,7 +3647,7 @@ func (x *SQLCPlugin_File) String() string {
 func (*SQLCPlugin_File) ProtoMessage() {}
 
 func (x *SQLCPlugin_File) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[44]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3410,7 +3660,7 @@ func (x *SQLCPlugin_File) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_File.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_File) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 0}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{45, 0}
 }
 
 func (x *SQLCPlugin_File) GetName() string {
@@ -3440,7 +3690,7 @@ type SQLCPlugin_Settings struct {
 
 func (x *SQLCPlugin_Settings) Reset() {
 	*x = SQLCPlugin_Settings{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[45]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3452,7 +3702,7 @@ func (x *SQLCPlugin_Settings) String() string {
 func (*SQLCPlugin_Settings) ProtoMessage() {}
 
 func (x *SQLCPlugin_Settings) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[45]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3465,7 +3715,7 @@ func (x *SQLCPlugin_Settings) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Settings.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Settings) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 1}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{45, 1}
 }
 
 func (x *SQLCPlugin_Settings) GetVersion() string {
@@ -3517,7 +3767,7 @@ type SQLCPlugin_Codegen struct {
 
 func (x *SQLCPlugin_Codegen) Reset() {
 	*x = SQLCPlugin_Codegen{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[46]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3529,7 +3779,7 @@ func (x *SQLCPlugin_Codegen) String() string {
 func (*SQLCPlugin_Codegen) ProtoMessage() {}
 
 func (x *SQLCPlugin_Codegen) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[46]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3542,7 +3792,7 @@ func (x *SQLCPlugin_Codegen) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Codegen.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Codegen) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 2}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{45, 2}
 }
 
 func (x *SQLCPlugin_Codegen) GetOut() string {
@@ -3599,7 +3849,7 @@ type SQLCPlugin_Catalog struct {
 
 func (x *SQLCPlugin_Catalog) Reset() {
 	*x = SQLCPlugin_Catalog{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[47]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3611,7 +3861,7 @@ func (x *SQLCPlugin_Catalog) String() string {
 func (*SQLCPlugin_Catalog) ProtoMessage() {}
 
 func (x *SQLCPlugin_Catalog) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[47]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3624,7 +3874,7 @@ func (x *SQLCPlugin_Catalog) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Catalog.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Catalog) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 3}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{45, 3}
 }
 
 func (x *SQLCPlugin_Catalog) GetComment() string {
@@ -3668,7 +3918,7 @@ type SQLCPlugin_Schema struct {
 
 func (x *SQLCPlugin_Schema) Reset() {
 	*x = SQLCPlugin_Schema{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[48]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3680,7 +3930,7 @@ func (x *SQLCPlugin_Schema) String() string {
 func (*SQLCPlugin_Schema) ProtoMessage() {}
 
 func (x *SQLCPlugin_Schema) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[48]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3693,7 +3943,7 @@ func (x *SQLCPlugin_Schema) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Schema.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Schema) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 4}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{45, 4}
 }
 
 func (x *SQLCPlugin_Schema) GetComment() string {
@@ -3741,7 +3991,7 @@ type SQLCPlugin_CompositeType struct {
 
 func (x *SQLCPlugin_CompositeType) Reset() {
 	*x = SQLCPlugin_CompositeType{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[49]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3753,7 +4003,7 @@ func (x *SQLCPlugin_CompositeType) String() string {
 func (*SQLCPlugin_CompositeType) ProtoMessage() {}
 
 func (x *SQLCPlugin_CompositeType) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[49]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3766,7 +4016,7 @@ func (x *SQLCPlugin_CompositeType) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_CompositeType.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_CompositeType) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 5}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{45, 5}
 }
 
 func (x *SQLCPlugin_CompositeType) GetName() string {
@@ -3794,7 +4044,7 @@ type SQLCPlugin_Enum struct {
 
 func (x *SQLCPlugin_Enum) Reset() {
 	*x = SQLCPlugin_Enum{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[50]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3806,7 +4056,7 @@ func (x *SQLCPlugin_Enum) String() string {
 func (*SQLCPlugin_Enum) ProtoMessage() {}
 
 func (x *SQLCPlugin_Enum) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[50]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3819,7 +4069,7 @@ func (x *SQLCPlugin_Enum) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Enum.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Enum) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 6}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{45, 6}
 }
 
 func (x *SQLCPlugin_Enum) GetName() string {
@@ -3854,7 +4104,7 @@ type SQLCPlugin_Table struct {
 
 func (x *SQLCPlugin_Table) Reset() {
 	*x = SQLCPlugin_Table{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[51]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3866,7 +4116,7 @@ func (x *SQLCPlugin_Table) String() string {
 func (*SQLCPlugin_Table) ProtoMessage() {}
 
 func (x *SQLCPlugin_Table) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[51]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3879,7 +4129,7 @@ func (x *SQLCPlugin_Table) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Table.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Table) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 7}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{45, 7}
 }
 
 func (x *SQLCPlugin_Table) GetRel() *SQLCPlugin_Identifier {
@@ -3914,7 +4164,7 @@ type SQLCPlugin_Identifier struct {
 
 func (x *SQLCPlugin_Identifier) Reset() {
 	*x = SQLCPlugin_Identifier{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[52]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3926,7 +4176,7 @@ func (x *SQLCPlugin_Identifier) String() string {
 func (*SQLCPlugin_Identifier) ProtoMessage() {}
 
 func (x *SQLCPlugin_Identifier) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[52]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3939,7 +4189,7 @@ func (x *SQLCPlugin_Identifier) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Identifier.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Identifier) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 8}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{45, 8}
 }
 
 func (x *SQLCPlugin_Identifier) GetCatalog() string {
@@ -3988,7 +4238,7 @@ type SQLCPlugin_Column struct {
 
 func (x *SQLCPlugin_Column) Reset() {
 	*x = SQLCPlugin_Column{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[53]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4000,7 +4250,7 @@ func (x *SQLCPlugin_Column) String() string {
 func (*SQLCPlugin_Column) ProtoMessage() {}
 
 func (x *SQLCPlugin_Column) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[53]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4013,7 +4263,7 @@ func (x *SQLCPlugin_Column) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Column.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Column) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 9}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{45, 9}
 }
 
 func (x *SQLCPlugin_Column) GetName() string {
@@ -4144,7 +4394,7 @@ type SQLCPlugin_Query struct {
 
 func (x *SQLCPlugin_Query) Reset() {
 	*x = SQLCPlugin_Query{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[54]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4156,7 +4406,7 @@ func (x *SQLCPlugin_Query) String() string {
 func (*SQLCPlugin_Query) ProtoMessage() {}
 
 func (x *SQLCPlugin_Query) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[54]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4169,7 +4419,7 @@ func (x *SQLCPlugin_Query) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Query.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Query) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 10}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{45, 10}
 }
 
 func (x *SQLCPlugin_Query) GetText() string {
@@ -4238,7 +4488,7 @@ type SQLCPlugin_Parameter struct {
 
 func (x *SQLCPlugin_Parameter) Reset() {
 	*x = SQLCPlugin_Parameter{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[55]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4250,7 +4500,7 @@ func (x *SQLCPlugin_Parameter) String() string {
 func (*SQLCPlugin_Parameter) ProtoMessage() {}
 
 func (x *SQLCPlugin_Parameter) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[55]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4263,7 +4513,7 @@ func (x *SQLCPlugin_Parameter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Parameter.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Parameter) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 11}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{45, 11}
 }
 
 func (x *SQLCPlugin_Parameter) GetNumber() int32 {
@@ -4294,7 +4544,7 @@ type SQLCPlugin_GenerateRequest struct {
 
 func (x *SQLCPlugin_GenerateRequest) Reset() {
 	*x = SQLCPlugin_GenerateRequest{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[56]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4306,7 +4556,7 @@ func (x *SQLCPlugin_GenerateRequest) String() string {
 func (*SQLCPlugin_GenerateRequest) ProtoMessage() {}
 
 func (x *SQLCPlugin_GenerateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[56]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4319,7 +4569,7 @@ func (x *SQLCPlugin_GenerateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_GenerateRequest.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_GenerateRequest) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 12}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{45, 12}
 }
 
 func (x *SQLCPlugin_GenerateRequest) GetSettings() *SQLCPlugin_Settings {
@@ -4373,7 +4623,7 @@ type SQLCPlugin_GenerateResponse struct {
 
 func (x *SQLCPlugin_GenerateResponse) Reset() {
 	*x = SQLCPlugin_GenerateResponse{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[57]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4385,7 +4635,7 @@ func (x *SQLCPlugin_GenerateResponse) String() string {
 func (*SQLCPlugin_GenerateResponse) ProtoMessage() {}
 
 func (x *SQLCPlugin_GenerateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[57]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4398,7 +4648,7 @@ func (x *SQLCPlugin_GenerateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_GenerateResponse.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_GenerateResponse) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 13}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{45, 13}
 }
 
 func (x *SQLCPlugin_GenerateResponse) GetFiles() []*SQLCPlugin_File {
@@ -4417,7 +4667,7 @@ type SQLCPlugin_Codegen_Process struct {
 
 func (x *SQLCPlugin_Codegen_Process) Reset() {
 	*x = SQLCPlugin_Codegen_Process{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[58]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4429,7 +4679,7 @@ func (x *SQLCPlugin_Codegen_Process) String() string {
 func (*SQLCPlugin_Codegen_Process) ProtoMessage() {}
 
 func (x *SQLCPlugin_Codegen_Process) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[58]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4442,7 +4692,7 @@ func (x *SQLCPlugin_Codegen_Process) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Codegen_Process.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Codegen_Process) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 2, 0}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{45, 2, 0}
 }
 
 func (x *SQLCPlugin_Codegen_Process) GetCmd() string {
@@ -4462,7 +4712,7 @@ type SQLCPlugin_Codegen_WASM struct {
 
 func (x *SQLCPlugin_Codegen_WASM) Reset() {
 	*x = SQLCPlugin_Codegen_WASM{}
-	mi := &file_encore_daemon_daemon_proto_msgTypes[59]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4474,7 +4724,7 @@ func (x *SQLCPlugin_Codegen_WASM) String() string {
 func (*SQLCPlugin_Codegen_WASM) ProtoMessage() {}
 
 func (x *SQLCPlugin_Codegen_WASM) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_daemon_daemon_proto_msgTypes[59]
+	mi := &file_encore_daemon_daemon_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4487,7 +4737,7 @@ func (x *SQLCPlugin_Codegen_WASM) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLCPlugin_Codegen_WASM.ProtoReflect.Descriptor instead.
 func (*SQLCPlugin_Codegen_WASM) Descriptor() ([]byte, []int) {
-	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{43, 2, 1}
+	return file_encore_daemon_daemon_proto_rawDescGZIP(), []int{45, 2, 1}
 }
 
 func (x *SQLCPlugin_Codegen_WASM) GetUrl() string {
@@ -4759,7 +5009,7 @@ const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"\x0fTelemetryConfig\x12\x17\n" +
 	"\aanon_id\x18\x01 \x01(\tR\x06anonId\x12\x18\n" +
 	"\aenabled\x18\x02 \x01(\bR\aenabled\x12\x14\n" +
-	"\x05debug\x18\x03 \x01(\bR\x05debug\"\x8c\x02\n" +
+	"\x05debug\x18\x03 \x01(\bR\x05debug\"\xbc\x03\n" +
 	"\x0fDumpMetaRequest\x12\x19\n" +
 	"\bapp_root\x18\x01 \x01(\tR\aappRoot\x12\x1f\n" +
 	"\vworking_dir\x18\x02 \x01(\tR\n" +
@@ -4767,13 +5017,38 @@ const file_encore_daemon_daemon_proto_rawDesc = "" +
 	"\aenviron\x18\x03 \x03(\tR\aenviron\x12\x1f\n" +
 	"\vparse_tests\x18\x04 \x01(\bR\n" +
 	"parseTests\x12=\n" +
-	"\x06format\x18\x05 \x01(\x0e2%.encore.daemon.DumpMetaRequest.FormatR\x06format\"C\n" +
+	"\x06format\x18\x05 \x01(\x0e2%.encore.daemon.DumpMetaRequest.FormatR\x06format\x12\x1d\n" +
+	"\aservice\x18\x06 \x01(\tH\x00R\aservice\x88\x01\x01\x12\x18\n" +
+	"\acompact\x18\a \x01(\bR\acompact\x12\x1d\n" +
+	"\n" +
+	"camel_case\x18\b \x01(\bR\tcamelCase\x12#\n" +
+	"\rcheck_secrets\x18\t \x01(\bR\fcheckSecrets\x12%\n" +
+	"\x0estrict_secrets\x18\n" +
+	" \x01(\bR\rstrictSecrets\"C\n" +
 	"\x06Format\x12\x16\n" +
 	"\x12FORMAT_UNSPECIFIED\x10\x00\x12\x0f\n" +
 	"\vFORMAT_JSON\x10\x01\x12\x10\n" +
-	"\fFORMAT_PROTO\x10\x02\"&\n" +
+	"\fFORMAT_PROTO\x10\x02B\n" +
+	"\n" +
+	"\b_service\"O\n" +
 	"\x10DumpMetaResponse\x12\x12\n" +
-	"\x04meta\x18\x01 \x01(\fR\x04meta\"\xcb\x15\n" +
+	"\x04meta\x18\x01 \x01(\fR\x04meta\x12'\n" +
+	"\x0fmissing_secrets\x18\x02 \x03(\tR\x0emissingSecrets\"@\n" +
+	"\vParseErrors\x121\n" +
+	"\x06errors\x18\x01 \x03(\v2\x19.encore.daemon.ParseErrorR\x06errors\"\x99\x02\n" +
+	"\n" +
+	"ParseError\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
+	"\asummary\x18\x02 \x01(\tR\asummary\x12@\n" +
+	"\tlocations\x18\x03 \x03(\v2\".encore.daemon.ParseError.LocationR\tlocations\x1a\x98\x01\n" +
+	"\bLocation\x12\x12\n" +
+	"\x04file\x18\x01 \x01(\tR\x04file\x12\x12\n" +
+	"\x04line\x18\x02 \x01(\x05R\x04line\x12\x16\n" +
+	"\x06column\x18\x03 \x01(\x05R\x06column\x12\x19\n" +
+	"\bend_line\x18\x04 \x01(\x05R\aendLine\x12\x1d\n" +
+	"\n" +
+	"end_column\x18\x05 \x01(\x05R\tendColumn\x12\x12\n" +
+	"\x04text\x18\x06 \x01(\tR\x04text\"\xcb\x15\n" +
 	"\n" +
 	"SQLCPlugin\x1a6\n" +
 	"\x04File\x12\x12\n" +
@@ -4919,7 +5194,7 @@ func file_encore_daemon_daemon_proto_rawDescGZIP() []byte {
 }
 
 var file_encore_daemon_daemon_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_encore_daemon_daemon_proto_msgTypes = make([]protoimpl.MessageInfo, 60)
+var file_encore_daemon_daemon_proto_msgTypes = make([]protoimpl.MessageInfo, 63)
 var file_encore_daemon_daemon_proto_goTypes = []any{
 	(DBRole)(0),                         // 0: encore.daemon.DBRole
 	(DBClusterType)(0),                  // 1: encore.daemon.DBClusterType
@@ -4969,24 +5244,27 @@ var file_encore_daemon_daemon_proto_goTypes = []any{
 	(*TelemetryConfig)(nil),             // 45: encore.daemon.TelemetryConfig
 	(*DumpMetaRequest)(nil),             // 46: encore.daemon.DumpMetaRequest
 	(*DumpMetaResponse)(nil),            // 47: encore.daemon.DumpMetaResponse
-	(*SQLCPlugin)(nil),                  // 48: encore.daemon.SQLCPlugin
-	(*SQLCPlugin_File)(nil),             // 49: encore.daemon.SQLCPlugin.File
-	(*SQLCPlugin_Settings)(nil),         // 50: encore.daemon.SQLCPlugin.Settings
-	(*SQLCPlugin_Codegen)(nil),          // 51: encore.daemon.SQLCPlugin.Codegen
-	(*SQLCPlugin_Catalog)(nil),          // 52: encore.daemon.SQLCPlugin.Catalog
-	(*SQLCPlugin_Schema)(nil),           // 53: encore.daemon.SQLCPlugin.Schema
-	(*SQLCPlugin_CompositeType)(nil),    // 54: encore.daemon.SQLCPlugin.CompositeType
-	(*SQLCPlugin_Enum)(nil),             // 55: encore.daemon.SQLCPlugin.Enum
-	(*SQLCPlugin_Table)(nil),            // 56: encore.daemon.SQLCPlugin.Table
-	(*SQLCPlugin_Identifier)(nil),       // 57: encore.daemon.SQLCPlugin.Identifier
-	(*SQLCPlugin_Column)(nil),           // 58: encore.daemon.SQLCPlugin.Column
-	(*SQLCPlugin_Query)(nil),            // 59: encore.daemon.SQLCPlugin.Query
-	(*SQLCPlugin_Parameter)(nil),        // 60: encore.daemon.SQLCPlugin.Parameter
-	(*SQLCPlugin_GenerateRequest)(nil),  // 61: encore.daemon.SQLCPlugin.GenerateRequest
-	(*SQLCPlugin_GenerateResponse)(nil), // 62: encore.daemon.SQLCPlugin.GenerateResponse
-	(*SQLCPlugin_Codegen_Process)(nil),  // 63: encore.daemon.SQLCPlugin.Codegen.Process
-	(*SQLCPlugin_Codegen_WASM)(nil),     // 64: encore.daemon.SQLCPlugin.Codegen.WASM
-	(*emptypb.Empty)(nil),               // 65: google.protobuf.Empty
+	(*ParseErrors)(nil),                 // 48: encore.daemon.ParseErrors
+	(*ParseError)(nil),                  // 49: encore.daemon.ParseError
+	(*SQLCPlugin)(nil),                  // 50: encore.daemon.SQLCPlugin
+	(*ParseError_Location)(nil),         // 51: encore.daemon.ParseError.Location
+	(*SQLCPlugin_File)(nil),             // 52: encore.daemon.SQLCPlugin.File
+	(*SQLCPlugin_Settings)(nil),         // 53: encore.daemon.SQLCPlugin.Settings
+	(*SQLCPlugin_Codegen)(nil),          // 54: encore.daemon.SQLCPlugin.Codegen
+	(*SQLCPlugin_Catalog)(nil),          // 55: encore.daemon.SQLCPlugin.Catalog
+	(*SQLCPlugin_Schema)(nil),           // 56: encore.daemon.SQLCPlugin.Schema
+	(*SQLCPlugin_CompositeType)(nil),    // 57: encore.daemon.SQLCPlugin.CompositeType
+	(*SQLCPlugin_Enum)(nil),             // 58: encore.daemon.SQLCPlugin.Enum
+	(*SQLCPlugin_Table)(nil),            // 59: encore.daemon.SQLCPlugin.Table
+	(*SQLCPlugin_Identifier)(nil),       // 60: encore.daemon.SQLCPlugin.Identifier
+	(*SQLCPlugin_Column)(nil),           // 61: encore.daemon.SQLCPlugin.Column
+	(*SQLCPlugin_Query)(nil),            // 62: encore.daemon.SQLCPlugin.Query
+	(*SQLCPlugin_Parameter)(nil),        // 63: encore.daemon.SQLCPlugin.Parameter
+	(*SQLCPlugin_GenerateRequest)(nil),  // 64: encore.daemon.SQLCPlugin.GenerateRequest
+	(*SQLCPlugin_GenerateResponse)(nil), // 65: encore.daemon.SQLCPlugin.GenerateResponse
+	(*SQLCPlugin_Codegen_Process)(nil),  // 66: encore.daemon.SQLCPlugin.Codegen.Process
+	(*SQLCPlugin_Codegen_WASM)(nil),     // 67: encore.daemon.SQLCPlugin.Codegen.WASM
+	(*emptypb.Empty)(nil),               // 68: google.protobuf.Empty
 }
 var file_encore_daemon_daemon_proto_depIdxs = []int32{
 	6,  // 0: encore.daemon.CommandMessage.output:type_name -> encore.daemon.CommandOutput
@@ -5009,75 +5287,77 @@ var file_encore_daemon_daemon_proto_depIdxs = []int32{
 	1,  // 17: encore.daemon.DBResetRequest.cluster_type:type_name -> encore.daemon.DBClusterType
 	39, // 18: encore.daemon.ListNamespacesResponse.namespaces:type_name -> encore.daemon.Namespace
 	4,  // 19: encore.daemon.DumpMetaRequest.format:type_name -> encore.daemon.DumpMetaRequest.Format
-	51, // 20: encore.daemon.SQLCPlugin.Settings.codegen:type_name -> encore.daemon.SQLCPlugin.Codegen
-	63, // 21: encore.daemon.SQLCPlugin.Codegen.process:type_name -> encore.daemon.SQLCPlugin.Codegen.Process
-	64, // 22: encore.daemon.SQLCPlugin.Codegen.wasm:type_name -> encore.daemon.SQLCPlugin.Codegen.WASM
-	53, // 23: encore.daemon.SQLCPlugin.Catalog.schemas:type_name -> encore.daemon.SQLCPlugin.Schema
-	56, // 24: encore.daemon.SQLCPlugin.Schema.tables:type_name -> encore.daemon.SQLCPlugin.Table
-	55, // 25: encore.daemon.SQLCPlugin.Schema.enums:type_name -> encore.daemon.SQLCPlugin.Enum
-	54, // 26: encore.daemon.SQLCPlugin.Schema.composite_types:type_name -> encore.daemon.SQLCPlugin.CompositeType
-	57, // 27: encore.daemon.SQLCPlugin.Table.rel:type_name -> encore.daemon.SQLCPlugin.Identifier
-	58, // 28: encore.daemon.SQLCPlugin.Table.columns:type_name -> encore.daemon.SQLCPlugin.Column
-	57, // 29: encore.daemon.SQLCPlugin.Column.table:type_name -> encore.daemon.SQLCPlugin.Identifier
-	57, // 30: encore.daemon.SQLCPlugin.Column.type:type_name -> encore.daemon.SQLCPlugin.Identifier
-	57, // 31: encore.daemon.SQLCPlugin.Column.embed_table:type_name -> encore.daemon.SQLCPlugin.Identifier
-	58, // 32: encore.daemon.SQLCPlugin.Query.columns:type_name -> encore.daemon.SQLCPlugin.Column
-	60, // 33: encore.daemon.SQLCPlugin.Query.params:type_name -> encore.daemon.SQLCPlugin.Parameter
-	57, // 34: encore.daemon.SQLCPlugin.Query.insert_into_table:type_name -> encore.daemon.SQLCPlugin.Identifier
-	58, // 35: encore.daemon.SQLCPlugin.Parameter.column:type_name -> encore.daemon.SQLCPlugin.Column
-	50, // 36: encore.daemon.SQLCPlugin.GenerateRequest.settings:type_name -> encore.daemon.SQLCPlugin.Settings
-	52, // 37: encore.daemon.SQLCPlugin.GenerateRequest.catalog:type_name -> encore.daemon.SQLCPlugin.Catalog
-	59, // 38: encore.daemon.SQLCPlugin.GenerateRequest.queries:type_name -> encore.daemon.SQLCPlugin.Query
-	49, // 39: encore.daemon.SQLCPlugin.GenerateResponse.files:type_name -> encore.daemon.SQLCPlugin.File
-	11, // 40: encore.daemon.Daemon.Run:input_type -> encore.daemon.RunRequest
-	12, // 41: encore.daemon.Daemon.RunSpec:input_type -> encore.daemon.RunSpecRequest
-	18, // 42: encore.daemon.Daemon.Test:input_type -> encore.daemon.TestRequest
-	19, // 43: encore.daemon.Daemon.TestSpec:input_type -> encore.daemon.TestSpecRequest
-	21, // 44: encore.daemon.Daemon.ExecScript:input_type -> encore.daemon.ExecScriptRequest
-	22, // 45: encore.daemon.Daemon.ExecSpec:input_type -> encore.daemon.ExecSpecRequest
-	25, // 46: encore.daemon.Daemon.Check:input_type -> encore.daemon.CheckRequest
-	26, // 47: encore.daemon.Daemon.Export:input_type -> encore.daemon.ExportRequest
-	28, // 48: encore.daemon.Daemon.DBConnect:input_type -> encore.daemon.DBConnectRequest
-	30, // 49: encore.daemon.Daemon.DBProxy:input_type -> encore.daemon.DBProxyRequest
-	31, // 50: encore.daemon.Daemon.DBReset:input_type -> encore.daemon.DBResetRequest
-	32, // 51: encore.daemon.Daemon.GenClient:input_type -> encore.daemon.GenClientRequest
-	34, // 52: encore.daemon.Daemon.GenWrappers:input_type -> encore.daemon.GenWrappersRequest
-	36, // 53: encore.daemon.Daemon.SecretsRefresh:input_type -> encore.daemon.SecretsRefreshRequest
-	65, // 54: encore.daemon.Daemon.Version:input_type -> google.protobuf.Empty
-	40, // 55: encore.daemon.Daemon.CreateNamespace:input_type -> encore.daemon.CreateNamespaceRequest
-	41, // 56: encore.daemon.Daemon.SwitchNamespace:input_type -> encore.daemon.SwitchNamespaceRequest
-	42, // 57: encore.daemon.Daemon.ListNamespaces:input_type -> encore.daemon.ListNamespacesRequest
-	43, // 58: encore.daemon.Daemon.DeleteNamespace:input_type -> encore.daemon.DeleteNamespaceRequest
-	46, // 59: encore.daemon.Daemon.DumpMeta:input_type -> encore.daemon.DumpMetaRequest
-	45, // 60: encore.daemon.Daemon.Telemetry:input_type -> encore.daemon.TelemetryConfig
-	9,  // 61: encore.daemon.Daemon.CreateApp:input_type -> encore.daemon.CreateAppRequest
-	5,  // 62: encore.daemon.Daemon.Run:output_type -> encore.daemon.CommandMessage
-	15, // 63: encore.daemon.Daemon.RunSpec:output_type -> encore.daemon.RunSpecMessage
-	5,  // 64: encore.daemon.Daemon.Test:output_type -> encore.daemon.CommandMessage
-	20, // 65: encore.daemon.Daemon.TestSpec:output_type -> encore.daemon.TestSpecResponse
-	5,  // 66: encore.daemon.Daemon.ExecScript:output_type -> encore.daemon.CommandMessage
-	23, // 67: encore.daemon.Daemon.ExecSpec:output_type -> encore.daemon.ExecSpecMessage
-	5,  // 68: encore.daemon.Daemon.Check:output_type -> encore.daemon.CommandMessage
-	5,  // 69: encore.daemon.Daemon.Export:output_type -> encore.daemon.CommandMessage
-	29, // 70: encore.daemon.Daemon.DBConnect:output_type -> encore.daemon.DBConnectResponse
-	5,  // 71: encore.daemon.Daemon.DBProxy:output_type -> encore.daemon.CommandMessage
-	5,  // 72: encore.daemon.Daemon.DBReset:output_type -> encore.daemon.CommandMessage
-	33, // 73: encore.daemon.Daemon.GenClient:output_type -> encore.daemon.GenClientResponse
-	35, // 74: encore.daemon.Daemon.GenWrappers:output_type -> encore.daemon.GenWrappersResponse
-	37, // 75: encore.daemon.Daemon.SecretsRefresh:output_type -> encore.daemon.SecretsRefreshResponse
-	38, // 76: encore.daemon.Daemon.Version:output_type -> encore.daemon.VersionResponse
-	39, // 77: encore.daemon.Daemon.CreateNamespace:output_type -> encore.daemon.Namespace
-	39, // 78: encore.daemon.Daemon.SwitchNamespace:output_type -> encore.daemon.Namespace
-	44, // 79: encore.daemon.Daemon.ListNamespaces:output_type -> encore.daemon.ListNamespacesResponse
-	65, // 80: encore.daemon.Daemon.DeleteNamespace:output_type -> google.protobuf.Empty
-	47, // 81: encore.daemon.Daemon.DumpMeta:output_type -> encore.daemon.DumpMetaResponse
-	65, // 82: encore.daemon.Daemon.Telemetry:output_type -> google.protobuf.Empty
-	10, // 83: encore.daemon.Daemon.CreateApp:output_type -> encore.daemon.CreateAppResponse
-	62, // [62:84] is the sub-list for method output_type
-	40, // [40:62] is the sub-list for method input_type
-	40, // [40:40] is the sub-list for extension type_name
-	40, // [40:40] is the sub-list for extension extendee
-	0,  // [0:40] is the sub-list for field type_name
+	49, // 20: encore.daemon.ParseErrors.errors:type_name -> encore.daemon.ParseError
+	51, // 21: encore.daemon.ParseError.locations:type_name -> encore.daemon.ParseError.Location
+	54, // 22: encore.daemon.SQLCPlugin.Settings.codegen:type_name -> encore.daemon.SQLCPlugin.Codegen
+	66, // 23: encore.daemon.SQLCPlugin.Codegen.process:type_name -> encore.daemon.SQLCPlugin.Codegen.Process
+	67, // 24: encore.daemon.SQLCPlugin.Codegen.wasm:type_name -> encore.daemon.SQLCPlugin.Codegen.WASM
+	56, // 25: encore.daemon.SQLCPlugin.Catalog.schemas:type_name -> encore.daemon.SQLCPlugin.Schema
+	59, // 26: encore.daemon.SQLCPlugin.Schema.tables:type_name -> encore.daemon.SQLCPlugin.Table
+	58, // 27: encore.daemon.SQLCPlugin.Schema.enums:type_name -> encore.daemon.SQLCPlugin.Enum
+	57, // 28: encore.daemon.SQLCPlugin.Schema.composite_types:type_name -> encore.daemon.SQLCPlugin.CompositeType
+	60, // 29: encore.daemon.SQLCPlugin.Table.rel:type_name -> encore.daemon.SQLCPlugin.Identifier
+	61, // 30: encore.daemon.SQLCPlugin.Table.columns:type_name -> encore.daemon.SQLCPlugin.Column
+	60, // 31: encore.daemon.SQLCPlugin.Column.table:type_name -> encore.daemon.SQLCPlugin.Identifier
+	60, // 32: encore.daemon.SQLCPlugin.Column.type:type_name -> encore.daemon.SQLCPlugin.Identifier
+	60, // 33: encore.daemon.SQLCPlugin.Column.embed_table:type_name -> encore.daemon.SQLCPlugin.Identifier
+	61, // 34: encore.daemon.SQLCPlugin.Query.columns:type_name -> encore.daemon.SQLCPlugin.Column
+	63, // 35: encore.daemon.SQLCPlugin.Query.params:type_name -> encore.daemon.SQLCPlugin.Parameter
+	60, // 36: encore.daemon.SQLCPlugin.Query.insert_into_table:type_name -> encore.daemon.SQLCPlugin.Identifier
+	61, // 37: encore.daemon.SQLCPlugin.Parameter.column:type_name -> encore.daemon.SQLCPlugin.Column
+	53, // 38: encore.daemon.SQLCPlugin.GenerateRequest.settings:type_name -> encore.daemon.SQLCPlugin.Settings
+	55, // 39: encore.daemon.SQLCPlugin.GenerateRequest.catalog:type_name -> encore.daemon.SQLCPlugin.Catalog
+	62, // 40: encore.daemon.SQLCPlugin.GenerateRequest.queries:type_name -> encore.daemon.SQLCPlugin.Query
+	52, // 41: encore.daemon.SQLCPlugin.GenerateResponse.files:type_name -> encore.daemon.SQLCPlugin.File
+	11, // 42: encore.daemon.Daemon.Run:input_type -> encore.daemon.RunRequest
+	12, // 43: encore.daemon.Daemon.RunSpec:input_type -> encore.daemon.RunSpecRequest
+	18, // 44: encore.daemon.Daemon.Test:input_type -> encore.daemon.TestRequest
+	19, // 45: encore.daemon.Daemon.TestSpec:input_type -> encore.daemon.TestSpecRequest
+	21, // 46: encore.daemon.Daemon.ExecScript:input_type -> encore.daemon.ExecScriptRequest
+	22, // 47: encore.daemon.Daemon.ExecSpec:input_type -> encore.daemon.ExecSpecRequest
+	25, // 48: encore.daemon.Daemon.Check:input_type -> encore.daemon.CheckRequest
+	26, // 49: encore.daemon.Daemon.Export:input_type -> encore.daemon.ExportRequest
+	28, // 50: encore.daemon.Daemon.DBConnect:input_type -> encore.daemon.DBConnectRequest
+	30, // 51: encore.daemon.Daemon.DBProxy:input_type -> encore.daemon.DBProxyRequest
+	31, // 52: encore.daemon.Daemon.DBReset:input_type -> encore.daemon.DBResetRequest
+	32, // 53: encore.daemon.Daemon.GenClient:input_type -> encore.daemon.GenClientRequest
+	34, // 54: encore.daemon.Daemon.GenWrappers:input_type -> encore.daemon.GenWrappersRequest
+	36, // 55: encore.daemon.Daemon.SecretsRefresh:input_type -> encore.daemon.SecretsRefreshRequest
+	68, // 56: encore.daemon.Daemon.Version:input_type -> google.protobuf.Empty
+	40, // 57: encore.daemon.Daemon.CreateNamespace:input_type -> encore.daemon.CreateNamespaceRequest
+	41, // 58: encore.daemon.Daemon.SwitchNamespace:input_type -> encore.daemon.SwitchNamespaceRequest
+	42, // 59: encore.daemon.Daemon.ListNamespaces:input_type -> encore.daemon.ListNamespacesRequest
+	43, // 60: encore.daemon.Daemon.DeleteNamespace:input_type -> encore.daemon.DeleteNamespaceRequest
+	46, // 61: encore.daemon.Daemon.DumpMeta:input_type -> encore.daemon.DumpMetaRequest
+	45, // 62: encore.daemon.Daemon.Telemetry:input_type -> encore.daemon.TelemetryConfig
+	9,  // 63: encore.daemon.Daemon.CreateApp:input_type -> encore.daemon.CreateAppRequest
+	5,  // 64: encore.daemon.Daemon.Run:output_type -> encore.daemon.CommandMessage
+	15, // 65: encore.daemon.Daemon.RunSpec:output_type -> encore.daemon.RunSpecMessage
+	5,  // 66: encore.daemon.Daemon.Test:output_type -> encore.daemon.CommandMessage
+	20, // 67: encore.daemon.Daemon.TestSpec:output_type -> encore.daemon.TestSpecResponse
+	5,  // 68: encore.daemon.Daemon.ExecScript:output_type -> encore.daemon.CommandMessage
+	23, // 69: encore.daemon.Daemon.ExecSpec:output_type -> encore.daemon.ExecSpecMessage
+	5,  // 70: encore.daemon.Daemon.Check:output_type -> encore.daemon.CommandMessage
+	5,  // 71: encore.daemon.Daemon.Export:output_type -> encore.daemon.CommandMessage
+	29, // 72: encore.daemon.Daemon.DBConnect:output_type -> encore.daemon.DBConnectResponse
+	5,  // 73: encore.daemon.Daemon.DBProxy:output_type -> encore.daemon.CommandMessage
+	5,  // 74: encore.daemon.Daemon.DBReset:output_type -> encore.daemon.CommandMessage
+	33, // 75: encore.daemon.Daemon.GenClient:output_type -> encore.daemon.GenClientResponse
+	35, // 76: encore.daemon.Daemon.GenWrappers:output_type -> encore.daemon.GenWrappersResponse
+	37, // 77: encore.daemon.Daemon.SecretsRefresh:output_type -> encore.daemon.SecretsRefreshResponse
+	38, // 78: encore.daemon.Daemon.Version:output_type -> encore.daemon.VersionResponse
+	39, // 79: encore.daemon.Daemon.CreateNamespace:output_type -> encore.daemon.Namespace
+	39, // 80: encore.daemon.Daemon.SwitchNamespace:output_type -> encore.daemon.Namespace
+	44, // 81: encore.daemon.Daemon.ListNamespaces:output_type -> encore.daemon.ListNamespacesResponse
+	68, // 82: encore.daemon.Daemon.DeleteNamespace:output_type -> google.protobuf.Empty
+	47, // 83: encore.daemon.Daemon.DumpMeta:output_type -> encore.daemon.DumpMetaResponse
+	68, // 84: encore.daemon.Daemon.Telemetry:output_type -> google.protobuf.Empty
+	10, // 85: encore.daemon.Daemon.CreateApp:output_type -> encore.daemon.CreateAppResponse
+	64, // [64:86] is the sub-list for method output_type
+	42, // [42:64] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_encore_daemon_daemon_proto_init() }
@@ -5115,13 +5395,14 @@ func file_encore_daemon_daemon_proto_init() {
 	file_encore_daemon_daemon_proto_msgTypes[26].OneofWrappers = []any{}
 	file_encore_daemon_daemon_proto_msgTypes[27].OneofWrappers = []any{}
 	file_encore_daemon_daemon_proto_msgTypes[34].OneofWrappers = []any{}
+	file_encore_daemon_daemon_proto_msgTypes[41].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_encore_daemon_daemon_proto_rawDesc), len(file_encore_daemon_daemon_proto_rawDesc)),
 			NumEnums:      5,
-			NumMessages:   60,
+			NumMessages:   63,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
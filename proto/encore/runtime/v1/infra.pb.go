@@ -9,6 +9,7 @@ package runtimev1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -76,6 +77,52 @@ func (ServerKind) EnumDescriptor() ([]byte, []int) {
 	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{0}
 }
 
+type SQLServer_Driver int32
+
+const (
+	SQLServer_DRIVER_POSTGRES SQLServer_Driver = 0
+	SQLServer_DRIVER_MYSQL    SQLServer_Driver = 1
+)
+
+// Enum value maps for SQLServer_Driver.
+var (
+	SQLServer_Driver_name = map[int32]string{
+		0: "DRIVER_POSTGRES",
+		1: "DRIVER_MYSQL",
+	}
+	SQLServer_Driver_value = map[string]int32{
+		"DRIVER_POSTGRES": 0,
+		"DRIVER_MYSQL":    1,
+	}
+)
+
+func (x SQLServer_Driver) Enum() *SQLServer_Driver {
+	p := new(SQLServer_Driver)
+	*p = x
+	return p
+}
+
+func (x SQLServer_Driver) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SQLServer_Driver) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_runtime_v1_infra_proto_enumTypes[1].Descriptor()
+}
+
+func (SQLServer_Driver) Type() protoreflect.EnumType {
+	return &file_encore_runtime_v1_infra_proto_enumTypes[1]
+}
+
+func (x SQLServer_Driver) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SQLServer_Driver.Descriptor instead.
+func (SQLServer_Driver) EnumDescriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{5, 0}
+}
+
 type PubSubTopic_DeliveryGuarantee int32
 
 const (
@@ -109,11 +156,11 @@ func (x PubSubTopic_DeliveryGuarantee) String() string {
 }
 
 func (PubSubTopic_DeliveryGuarantee) Descriptor() protoreflect.EnumDescriptor {
-	return file_encore_runtime_v1_infra_proto_enumTypes[1].Descriptor()
+	return file_encore_runtime_v1_infra_proto_enumTypes[2].Descriptor()
 }
 
 func (PubSubTopic_DeliveryGuarantee) Type() protoreflect.EnumType {
-	return &file_encore_runtime_v1_infra_proto_enumTypes[1]
+	return &file_encore_runtime_v1_infra_proto_enumTypes[2]
 }
 
 func (x PubSubTopic_DeliveryGuarantee) Number() protoreflect.EnumNumber {
@@ -122,7 +169,58 @@ func (x PubSubTopic_DeliveryGuarantee) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use PubSubTopic_DeliveryGuarantee.Descriptor instead.
 func (PubSubTopic_DeliveryGuarantee) EnumDescriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{16, 0}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{17, 0}
+}
+
+type Bucket_AccessMode int32
+
+const (
+	Bucket_ACCESS_MODE_UNSPECIFIED Bucket_AccessMode = 0
+	// Listing and reading objects, their metadata and urls.
+	Bucket_ACCESS_MODE_READ_ONLY Bucket_AccessMode = 1
+	// Read access, plus creating, updating and deleting objects.
+	Bucket_ACCESS_MODE_READ_WRITE Bucket_AccessMode = 2
+)
+
+// Enum value maps for Bucket_AccessMode.
+var (
+	Bucket_AccessMode_name = map[int32]string{
+		0: "ACCESS_MODE_UNSPECIFIED",
+		1: "ACCESS_MODE_READ_ONLY",
+		2: "ACCESS_MODE_READ_WRITE",
+	}
+	Bucket_AccessMode_value = map[string]int32{
+		"ACCESS_MODE_UNSPECIFIED": 0,
+		"ACCESS_MODE_READ_ONLY":   1,
+		"ACCESS_MODE_READ_WRITE":  2,
+	}
+)
+
+func (x Bucket_AccessMode) Enum() *Bucket_AccessMode {
+	p := new(Bucket_AccessMode)
+	*p = x
+	return p
+}
+
+func (x Bucket_AccessMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Bucket_AccessMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_runtime_v1_infra_proto_enumTypes[3].Descriptor()
+}
+
+func (Bucket_AccessMode) Type() protoreflect.EnumType {
+	return &file_encore_runtime_v1_infra_proto_enumTypes[3]
+}
+
+func (x Bucket_AccessMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Bucket_AccessMode.Descriptor instead.
+func (Bucket_AccessMode) EnumDescriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{20, 0}
 }
 
 type Infrastructure struct {
@@ -269,9 +367,11 @@ func (*SecretProvider_GcpSm) isSecretProvider_Provider() {}
 type SQLCluster struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The unique resource id for this cluster.
-	Rid           string         `protobuf:"bytes,1,opt,name=rid,proto3" json:"rid,omitempty"`
-	Servers       []*SQLServer   `protobuf:"bytes,2,rep,name=servers,proto3" json:"servers,omitempty"`
-	Databases     []*SQLDatabase `protobuf:"bytes,3,rep,name=databases,proto3" json:"databases,omitempty"`
+	Rid       string         `protobuf:"bytes,1,opt,name=rid,proto3" json:"rid,omitempty"`
+	Servers   []*SQLServer   `protobuf:"bytes,2,rep,name=servers,proto3" json:"servers,omitempty"`
+	Databases []*SQLDatabase `protobuf:"bytes,3,rep,name=databases,proto3" json:"databases,omitempty"`
+	// SSH tunnel to establish before connecting to the servers, if any.
+	SshTunnel     *SSHTunnel `protobuf:"bytes,4,opt,name=ssh_tunnel,json=sshTunnel,proto3,oneof" json:"ssh_tunnel,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -327,6 +427,76 @@ func (x *SQLCluster) GetDatabases() []*SQLDatabase {
 	return nil
 }
 
+func (x *SQLCluster) GetSshTunnel() *SSHTunnel {
+	if x != nil {
+		return x.SshTunnel
+	}
+	return nil
+}
+
+type SSHTunnel struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The bastion host to tunnel through, in "hostname:port" format.
+	BastionHost string `protobuf:"bytes,1,opt,name=bastion_host,json=bastionHost,proto3" json:"bastion_host,omitempty"`
+	// The user to authenticate to the bastion host as.
+	User string `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	// The PEM-encoded private key to authenticate with.
+	PrivateKey    *SecretData `protobuf:"bytes,3,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SSHTunnel) Reset() {
+	*x = SSHTunnel{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SSHTunnel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SSHTunnel) ProtoMessage() {}
+
+func (x *SSHTunnel) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SSHTunnel.ProtoReflect.Descriptor instead.
+func (*SSHTunnel) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SSHTunnel) GetBastionHost() string {
+	if x != nil {
+		return x.BastionHost
+	}
+	return ""
+}
+
+func (x *SSHTunnel) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *SSHTunnel) GetPrivateKey() *SecretData {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return nil
+}
+
 type TLSConfig struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Server CA Cert PEM to use for verifying the server's certificate.
@@ -338,13 +508,17 @@ type TLSConfig struct {
 	// If true, skips CA cert validation when connecting.
 	// This introduces significant vulnerabilities, and should only be used as a last resort.
 	DisableCaValidation bool `protobuf:"varint,3,opt,name=disable_ca_validation,json=disableCaValidation,proto3" json:"disable_ca_validation,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+	// The server name to verify the server's certificate against,
+	// when it differs from the host (e.g. when connecting through a proxy).
+	// If unset the host is used.
+	ServerName    *string `protobuf:"bytes,4,opt,name=server_name,json=serverName,proto3,oneof" json:"server_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TLSConfig) Reset() {
 	*x = TLSConfig{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[3]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -356,7 +530,7 @@ func (x *TLSConfig) String() string {
 func (*TLSConfig) ProtoMessage() {}
 
 func (x *TLSConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[3]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -369,7 +543,7 @@ func (x *TLSConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TLSConfig.ProtoReflect.Descriptor instead.
 func (*TLSConfig) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{3}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *TLSConfig) GetServerCaCert() string {
@@ -393,6 +567,13 @@ func (x *TLSConfig) GetDisableCaValidation() bool {
 	return false
 }
 
+func (x *TLSConfig) GetServerName() string {
+	if x != nil && x.ServerName != nil {
+		return *x.ServerName
+	}
+	return ""
+}
+
 type SQLServer struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The unique resource id for this server.
@@ -402,14 +583,16 @@ type SQLServer struct {
 	Host string     `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
 	Kind ServerKind `protobuf:"varint,3,opt,name=kind,proto3,enum=encore.runtime.v1.ServerKind" json:"kind,omitempty"`
 	// TLS configuration to use when connecting.
-	TlsConfig     *TLSConfig `protobuf:"bytes,4,opt,name=tls_config,json=tlsConfig,proto3,oneof" json:"tls_config,omitempty"`
+	TlsConfig *TLSConfig `protobuf:"bytes,4,opt,name=tls_config,json=tlsConfig,proto3,oneof" json:"tls_config,omitempty"`
+	// The database driver to use when connecting.
+	Driver        SQLServer_Driver `protobuf:"varint,5,opt,name=driver,proto3,enum=encore.runtime.v1.SQLServer_Driver" json:"driver,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SQLServer) Reset() {
 	*x = SQLServer{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[4]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -421,7 +604,7 @@ func (x *SQLServer) String() string {
 func (*SQLServer) ProtoMessage() {}
 
 func (x *SQLServer) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[4]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -434,7 +617,7 @@ func (x *SQLServer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLServer.ProtoReflect.Descriptor instead.
 func (*SQLServer) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{4}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *SQLServer) GetRid() string {
@@ -465,6 +648,13 @@ func (x *SQLServer) GetTlsConfig() *TLSConfig {
 	return nil
 }
 
+func (x *SQLServer) GetDriver() SQLServer_Driver {
+	if x != nil {
+		return x.Driver
+	}
+	return SQLServer_DRIVER_POSTGRES
+}
+
 type ClientCert struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The unique resource id for this certificate.
@@ -477,7 +667,7 @@ type ClientCert struct {
 
 func (x *ClientCert) Reset() {
 	*x = ClientCert{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[5]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -489,7 +679,7 @@ func (x *ClientCert) String() string {
 func (*ClientCert) ProtoMessage() {}
 
 func (x *ClientCert) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[5]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -502,7 +692,7 @@ func (x *ClientCert) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClientCert.ProtoReflect.Descriptor instead.
 func (*ClientCert) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{5}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ClientCert) GetRid() string {
@@ -534,13 +724,15 @@ type SQLRole struct {
 	Password *SecretData `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
 	// The client cert to use to authenticate, if any.
 	ClientCertRid *string `protobuf:"bytes,4,opt,name=client_cert_rid,json=clientCertRid,proto3,oneof" json:"client_cert_rid,omitempty"`
+	// How to rotate the role's credentials, if they are short-lived.
+	Rotation      *SQLRole_CredentialRotation `protobuf:"bytes,5,opt,name=rotation,proto3,oneof" json:"rotation,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SQLRole) Reset() {
 	*x = SQLRole{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[6]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -552,7 +744,7 @@ func (x *SQLRole) String() string {
 func (*SQLRole) ProtoMessage() {}
 
 func (x *SQLRole) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[6]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -565,7 +757,7 @@ func (x *SQLRole) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLRole.ProtoReflect.Descriptor instead.
 func (*SQLRole) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{6}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *SQLRole) GetRid() string {
@@ -596,6 +788,13 @@ func (x *SQLRole) GetClientCertRid() string {
 	return ""
 }
 
+func (x *SQLRole) GetRotation() *SQLRole_CredentialRotation {
+	if x != nil {
+		return x.Rotation
+	}
+	return nil
+}
+
 type SQLDatabase struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The unique resource id for this database.
@@ -604,14 +803,17 @@ type SQLDatabase struct {
 	// The physical name of the database in the cluster.
 	CloudName string `protobuf:"bytes,3,opt,name=cloud_name,json=cloudName,proto3" json:"cloud_name,omitempty"`
 	// Connection pools to use for connecting to the database.
-	ConnPools     []*SQLConnectionPool `protobuf:"bytes,4,rep,name=conn_pools,json=connPools,proto3" json:"conn_pools,omitempty"`
+	ConnPools []*SQLConnectionPool `protobuf:"bytes,4,rep,name=conn_pools,json=connPools,proto3" json:"conn_pools,omitempty"`
+	// The schema search path to set on new connections.
+	// If empty the server's default search path is used.
+	SearchPath    []string `protobuf:"bytes,5,rep,name=search_path,json=searchPath,proto3" json:"search_path,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SQLDatabase) Reset() {
 	*x = SQLDatabase{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[7]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -623,7 +825,7 @@ func (x *SQLDatabase) String() string {
 func (*SQLDatabase) ProtoMessage() {}
 
 func (x *SQLDatabase) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[7]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -636,7 +838,7 @@ func (x *SQLDatabase) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLDatabase.ProtoReflect.Descriptor instead.
 func (*SQLDatabase) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{7}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *SQLDatabase) GetRid() string {
@@ -667,6 +869,13 @@ func (x *SQLDatabase) GetConnPools() []*SQLConnectionPool {
 	return nil
 }
 
+func (x *SQLDatabase) GetSearchPath() []string {
+	if x != nil {
+		return x.SearchPath
+	}
+	return nil
+}
+
 type SQLConnectionPool struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Whether this connection pool is for read-only servers.
@@ -676,13 +885,25 @@ type SQLConnectionPool struct {
 	// The minimum and maximum number of connections to use.
 	MinConnections int32 `protobuf:"varint,3,opt,name=min_connections,json=minConnections,proto3" json:"min_connections,omitempty"`
 	MaxConnections int32 `protobuf:"varint,4,opt,name=max_connections,json=maxConnections,proto3" json:"max_connections,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// How often the pool sweeps for and closes idle connections.
+	// If unset the runtime uses its default reaping cadence.
+	IdleReapInterval *durationpb.Duration `protobuf:"bytes,5,opt,name=idle_reap_interval,json=idleReapInterval,proto3,oneof" json:"idle_reap_interval,omitempty"`
+	// Whether to open min_connections connections when the pool is created,
+	// instead of lazily on first use.
+	WarmupOnStart bool `protobuf:"varint,6,opt,name=warmup_on_start,json=warmupOnStart,proto3" json:"warmup_on_start,omitempty"`
+	// The maximum time a connection may be reused before it's closed.
+	// If unset connections are reused indefinitely.
+	MaxLifetime *durationpb.Duration `protobuf:"bytes,7,opt,name=max_lifetime,json=maxLifetime,proto3,oneof" json:"max_lifetime,omitempty"`
+	// The maximum time a connection may be idle before it's closed.
+	// If unset idle connections are kept indefinitely.
+	MaxIdleTime   *durationpb.Duration `protobuf:"bytes,8,opt,name=max_idle_time,json=maxIdleTime,proto3,oneof" json:"max_idle_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SQLConnectionPool) Reset() {
 	*x = SQLConnectionPool{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[8]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -694,7 +915,7 @@ func (x *SQLConnectionPool) String() string {
 func (*SQLConnectionPool) ProtoMessage() {}
 
 func (x *SQLConnectionPool) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[8]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -707,7 +928,7 @@ func (x *SQLConnectionPool) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLConnectionPool.ProtoReflect.Descriptor instead.
 func (*SQLConnectionPool) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{8}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *SQLConnectionPool) GetIsReadonly() bool {
@@ -738,6 +959,34 @@ func (x *SQLConnectionPool) GetMaxConnections() int32 {
 	return 0
 }
 
+func (x *SQLConnectionPool) GetIdleReapInterval() *durationpb.Duration {
+	if x != nil {
+		return x.IdleReapInterval
+	}
+	return nil
+}
+
+func (x *SQLConnectionPool) GetWarmupOnStart() bool {
+	if x != nil {
+		return x.WarmupOnStart
+	}
+	return false
+}
+
+func (x *SQLConnectionPool) GetMaxLifetime() *durationpb.Duration {
+	if x != nil {
+		return x.MaxLifetime
+	}
+	return nil
+}
+
+func (x *SQLConnectionPool) GetMaxIdleTime() *durationpb.Duration {
+	if x != nil {
+		return x.MaxIdleTime
+	}
+	return nil
+}
+
 type RedisCluster struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The unique resource id for this cluster.
@@ -753,7 +1002,7 @@ type RedisCluster struct {
 
 func (x *RedisCluster) Reset() {
 	*x = RedisCluster{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[9]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -765,7 +1014,7 @@ func (x *RedisCluster) String() string {
 func (*RedisCluster) ProtoMessage() {}
 
 func (x *RedisCluster) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[9]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -778,7 +1027,7 @@ func (x *RedisCluster) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RedisCluster.ProtoReflect.Descriptor instead.
 func (*RedisCluster) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{9}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *RedisCluster) GetRid() string {
@@ -826,7 +1075,7 @@ type RedisServer struct {
 
 func (x *RedisServer) Reset() {
 	*x = RedisServer{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[10]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -838,7 +1087,7 @@ func (x *RedisServer) String() string {
 func (*RedisServer) ProtoMessage() {}
 
 func (x *RedisServer) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[10]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -851,7 +1100,7 @@ func (x *RedisServer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RedisServer.ProtoReflect.Descriptor instead.
 func (*RedisServer) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{10}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *RedisServer) GetRid() string {
@@ -891,13 +1140,25 @@ type RedisConnectionPool struct {
 	// The minimum and maximum number of connections to use.
 	MinConnections int32 `protobuf:"varint,3,opt,name=min_connections,json=minConnections,proto3" json:"min_connections,omitempty"`
 	MaxConnections int32 `protobuf:"varint,4,opt,name=max_connections,json=maxConnections,proto3" json:"max_connections,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// How often the pool sweeps for and closes idle connections.
+	// If unset the runtime uses its default reaping cadence.
+	IdleReapInterval *durationpb.Duration `protobuf:"bytes,5,opt,name=idle_reap_interval,json=idleReapInterval,proto3,oneof" json:"idle_reap_interval,omitempty"`
+	// Whether to open min_connections connections when the pool is created,
+	// instead of lazily on first use.
+	WarmupOnStart bool `protobuf:"varint,6,opt,name=warmup_on_start,json=warmupOnStart,proto3" json:"warmup_on_start,omitempty"`
+	// The maximum time a connection may be reused before it's closed.
+	// If unset connections are reused indefinitely.
+	MaxLifetime *durationpb.Duration `protobuf:"bytes,7,opt,name=max_lifetime,json=maxLifetime,proto3,oneof" json:"max_lifetime,omitempty"`
+	// The maximum time a connection may be idle before it's closed.
+	// If unset idle connections are kept indefinitely.
+	MaxIdleTime   *durationpb.Duration `protobuf:"bytes,8,opt,name=max_idle_time,json=maxIdleTime,proto3,oneof" json:"max_idle_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RedisConnectionPool) Reset() {
 	*x = RedisConnectionPool{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[11]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -909,7 +1170,7 @@ func (x *RedisConnectionPool) String() string {
 func (*RedisConnectionPool) ProtoMessage() {}
 
 func (x *RedisConnectionPool) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[11]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -922,7 +1183,7 @@ func (x *RedisConnectionPool) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RedisConnectionPool.ProtoReflect.Descriptor instead.
 func (*RedisConnectionPool) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{11}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *RedisConnectionPool) GetIsReadonly() bool {
@@ -953,6 +1214,34 @@ func (x *RedisConnectionPool) GetMaxConnections() int32 {
 	return 0
 }
 
+func (x *RedisConnectionPool) GetIdleReapInterval() *durationpb.Duration {
+	if x != nil {
+		return x.IdleReapInterval
+	}
+	return nil
+}
+
+func (x *RedisConnectionPool) GetWarmupOnStart() bool {
+	if x != nil {
+		return x.WarmupOnStart
+	}
+	return false
+}
+
+func (x *RedisConnectionPool) GetMaxLifetime() *durationpb.Duration {
+	if x != nil {
+		return x.MaxLifetime
+	}
+	return nil
+}
+
+func (x *RedisConnectionPool) GetMaxIdleTime() *durationpb.Duration {
+	if x != nil {
+		return x.MaxIdleTime
+	}
+	return nil
+}
+
 type RedisRole struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The unique resource id for this role.
@@ -973,7 +1262,7 @@ type RedisRole struct {
 
 func (x *RedisRole) Reset() {
 	*x = RedisRole{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[12]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -985,7 +1274,7 @@ func (x *RedisRole) String() string {
 func (*RedisRole) ProtoMessage() {}
 
 func (x *RedisRole) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[12]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -998,7 +1287,7 @@ func (x *RedisRole) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RedisRole.ProtoReflect.Descriptor instead.
 func (*RedisRole) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{12}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *RedisRole) GetRid() string {
@@ -1077,7 +1366,7 @@ type RedisDatabase struct {
 
 func (x *RedisDatabase) Reset() {
 	*x = RedisDatabase{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[13]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1089,7 +1378,7 @@ func (x *RedisDatabase) String() string {
 func (*RedisDatabase) ProtoMessage() {}
 
 func (x *RedisDatabase) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[13]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1102,7 +1391,7 @@ func (x *RedisDatabase) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RedisDatabase.ProtoReflect.Descriptor instead.
 func (*RedisDatabase) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{13}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *RedisDatabase) GetRid() string {
@@ -1154,7 +1443,7 @@ type AppSecret struct {
 
 func (x *AppSecret) Reset() {
 	*x = AppSecret{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[14]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1166,7 +1455,7 @@ func (x *AppSecret) String() string {
 func (*AppSecret) ProtoMessage() {}
 
 func (x *AppSecret) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[14]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1179,7 +1468,7 @@ func (x *AppSecret) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AppSecret.ProtoReflect.Descriptor instead.
 func (*AppSecret) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{14}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *AppSecret) GetRid() string {
@@ -1216,6 +1505,7 @@ type PubSubCluster struct {
 	//	*PubSubCluster_Gcp
 	//	*PubSubCluster_Azure
 	//	*PubSubCluster_Nsq
+	//	*PubSubCluster_Kafka_
 	Provider      isPubSubCluster_Provider `protobuf_oneof:"provider"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -1223,7 +1513,7 @@ type PubSubCluster struct {
 
 func (x *PubSubCluster) Reset() {
 	*x = PubSubCluster{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[15]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1235,7 +1525,7 @@ func (x *PubSubCluster) String() string {
 func (*PubSubCluster) ProtoMessage() {}
 
 func (x *PubSubCluster) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[15]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1248,7 +1538,7 @@ func (x *PubSubCluster) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubSubCluster.ProtoReflect.Descriptor instead.
 func (*PubSubCluster) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{15}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *PubSubCluster) GetRid() string {
@@ -1324,7 +1614,16 @@ func (x *PubSubCluster) GetNsq() *PubSubCluster_NSQ {
 	return nil
 }
 
-type isPubSubCluster_Provider interface {
+func (x *PubSubCluster) GetKafka() *PubSubCluster_Kafka {
+	if x != nil {
+		if x, ok := x.Provider.(*PubSubCluster_Kafka_); ok {
+			return x.Kafka
+		}
+	}
+	return nil
+}
+
+type isPubSubCluster_Provider interface {
 	isPubSubCluster_Provider()
 }
 
@@ -1348,6 +1647,10 @@ type PubSubCluster_Nsq struct {
 	Nsq *PubSubCluster_NSQ `protobuf:"bytes,9,opt,name=nsq,proto3,oneof"`
 }
 
+type PubSubCluster_Kafka_ struct {
+	Kafka *PubSubCluster_Kafka `protobuf:"bytes,10,opt,name=kafka,proto3,oneof"`
+}
+
 func (*PubSubCluster_Encore) isPubSubCluster_Provider() {}
 
 func (*PubSubCluster_Aws) isPubSubCluster_Provider() {}
@@ -1358,6 +1661,8 @@ func (*PubSubCluster_Azure) isPubSubCluster_Provider() {}
 
 func (*PubSubCluster_Nsq) isPubSubCluster_Provider() {}
 
+func (*PubSubCluster_Kafka_) isPubSubCluster_Provider() {}
+
 type PubSubTopic struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The unique resource id for this topic.
@@ -1378,6 +1683,7 @@ type PubSubTopic struct {
 	// Types that are valid to be assigned to ProviderConfig:
 	//
 	//	*PubSubTopic_GcpConfig
+	//	*PubSubTopic_KafkaConfig_
 	ProviderConfig isPubSubTopic_ProviderConfig `protobuf_oneof:"provider_config"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
@@ -1385,7 +1691,7 @@ type PubSubTopic struct {
 
 func (x *PubSubTopic) Reset() {
 	*x = PubSubTopic{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[16]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1397,7 +1703,7 @@ func (x *PubSubTopic) String() string {
 func (*PubSubTopic) ProtoMessage() {}
 
 func (x *PubSubTopic) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[16]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1410,7 +1716,7 @@ func (x *PubSubTopic) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubSubTopic.ProtoReflect.Descriptor instead.
 func (*PubSubTopic) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{16}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *PubSubTopic) GetRid() string {
@@ -1464,16 +1770,31 @@ func (x *PubSubTopic) GetGcpConfig() *PubSubTopic_GCPConfig {
 	return nil
 }
 
+func (x *PubSubTopic) GetKafkaConfig() *PubSubTopic_KafkaConfig {
+	if x != nil {
+		if x, ok := x.ProviderConfig.(*PubSubTopic_KafkaConfig_); ok {
+			return x.KafkaConfig
+		}
+	}
+	return nil
+}
+
 type isPubSubTopic_ProviderConfig interface {
 	isPubSubTopic_ProviderConfig()
 }
 
 type PubSubTopic_GcpConfig struct {
-	GcpConfig *PubSubTopic_GCPConfig `protobuf:"bytes,10,opt,name=gcp_config,json=gcpConfig,proto3,oneof"` // Null: no provider-specific configuration.
+	GcpConfig *PubSubTopic_GCPConfig `protobuf:"bytes,10,opt,name=gcp_config,json=gcpConfig,proto3,oneof"`
+}
+
+type PubSubTopic_KafkaConfig_ struct {
+	KafkaConfig *PubSubTopic_KafkaConfig `protobuf:"bytes,11,opt,name=kafka_config,json=kafkaConfig,proto3,oneof"` // Null: no provider-specific configuration.
 }
 
 func (*PubSubTopic_GcpConfig) isPubSubTopic_ProviderConfig() {}
 
+func (*PubSubTopic_KafkaConfig_) isPubSubTopic_ProviderConfig() {}
+
 type PubSubSubscription struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The unique resource id for this subscription.
@@ -1489,6 +1810,9 @@ type PubSubSubscription struct {
 	// If true the application will not actively subscribe but wait
 	// for incoming messages to be pushed to it.
 	PushOnly bool `protobuf:"varint,6,opt,name=push_only,json=pushOnly,proto3" json:"push_only,omitempty"`
+	// The dead-letter policy for the subscription, if any.
+	// Messages that can't be delivered are forwarded to the dead-letter topic.
+	DeadLetter *PubSubSubscription_DeadLetterPolicy `protobuf:"bytes,7,opt,name=dead_letter,json=deadLetter,proto3,oneof" json:"dead_letter,omitempty"`
 	// Subscription-specific provider configuration.
 	// Not all providers require this, but it must always be set
 	// for the providers that are present.
@@ -1496,6 +1820,7 @@ type PubSubSubscription struct {
 	// Types that are valid to be assigned to ProviderConfig:
 	//
 	//	*PubSubSubscription_GcpConfig
+	//	*PubSubSubscription_KafkaConfig_
 	ProviderConfig isPubSubSubscription_ProviderConfig `protobuf_oneof:"provider_config"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
@@ -1503,7 +1828,7 @@ type PubSubSubscription struct {
 
 func (x *PubSubSubscription) Reset() {
 	*x = PubSubSubscription{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[17]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1515,7 +1840,7 @@ func (x *PubSubSubscription) String() string {
 func (*PubSubSubscription) ProtoMessage() {}
 
 func (x *PubSubSubscription) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[17]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1528,7 +1853,7 @@ func (x *PubSubSubscription) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubSubSubscription.ProtoReflect.Descriptor instead.
 func (*PubSubSubscription) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{17}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *PubSubSubscription) GetRid() string {
@@ -1573,6 +1898,13 @@ func (x *PubSubSubscription) GetPushOnly() bool {
 	return false
 }
 
+func (x *PubSubSubscription) GetDeadLetter() *PubSubSubscription_DeadLetterPolicy {
+	if x != nil {
+		return x.DeadLetter
+	}
+	return nil
+}
+
 func (x *PubSubSubscription) GetProviderConfig() isPubSubSubscription_ProviderConfig {
 	if x != nil {
 		return x.ProviderConfig
@@ -1589,21 +1921,39 @@ func (x *PubSubSubscription) GetGcpConfig() *PubSubSubscription_GCPConfig {
 	return nil
 }
 
+func (x *PubSubSubscription) GetKafkaConfig() *PubSubSubscription_KafkaConfig {
+	if x != nil {
+		if x, ok := x.ProviderConfig.(*PubSubSubscription_KafkaConfig_); ok {
+			return x.KafkaConfig
+		}
+	}
+	return nil
+}
+
 type isPubSubSubscription_ProviderConfig interface {
 	isPubSubSubscription_ProviderConfig()
 }
 
 type PubSubSubscription_GcpConfig struct {
-	GcpConfig *PubSubSubscription_GCPConfig `protobuf:"bytes,10,opt,name=gcp_config,json=gcpConfig,proto3,oneof"` // Null: no provider-specific configuration.
+	GcpConfig *PubSubSubscription_GCPConfig `protobuf:"bytes,10,opt,name=gcp_config,json=gcpConfig,proto3,oneof"`
+}
+
+type PubSubSubscription_KafkaConfig_ struct {
+	KafkaConfig *PubSubSubscription_KafkaConfig `protobuf:"bytes,11,opt,name=kafka_config,json=kafkaConfig,proto3,oneof"` // Null: no provider-specific configuration.
 }
 
 func (*PubSubSubscription_GcpConfig) isPubSubSubscription_ProviderConfig() {}
 
+func (*PubSubSubscription_KafkaConfig_) isPubSubSubscription_ProviderConfig() {}
+
 type BucketCluster struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The unique resource id for this cluster.
 	Rid     string    `protobuf:"bytes,1,opt,name=rid,proto3" json:"rid,omitempty"`
 	Buckets []*Bucket `protobuf:"bytes,2,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	// The default expiry for signed URLs, used when no TTL is specified
+	// when generating the URL. If unset the runtime default is used.
+	DefaultSignedUrlTtl *durationpb.Duration `protobuf:"bytes,3,opt,name=default_signed_url_ttl,json=defaultSignedUrlTtl,proto3,oneof" json:"default_signed_url_ttl,omitempty"`
 	// Types that are valid to be assigned to Provider:
 	//
 	//	*BucketCluster_S3_
@@ -1615,7 +1965,7 @@ type BucketCluster struct {
 
 func (x *BucketCluster) Reset() {
 	*x = BucketCluster{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[18]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1627,7 +1977,7 @@ func (x *BucketCluster) String() string {
 func (*BucketCluster) ProtoMessage() {}
 
 func (x *BucketCluster) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[18]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1640,7 +1990,7 @@ func (x *BucketCluster) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketCluster.ProtoReflect.Descriptor instead.
 func (*BucketCluster) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{18}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *BucketCluster) GetRid() string {
@@ -1657,6 +2007,13 @@ func (x *BucketCluster) GetBuckets() []*Bucket {
 	return nil
 }
 
+func (x *BucketCluster) GetDefaultSignedUrlTtl() *durationpb.Duration {
+	if x != nil {
+		return x.DefaultSignedUrlTtl
+	}
+	return nil
+}
+
 func (x *BucketCluster) GetProvider() isBucketCluster_Provider {
 	if x != nil {
 		return x.Provider
@@ -1714,13 +2071,21 @@ type Bucket struct {
 	// Public base URL for accessing objects in this bucket.
 	// Must be set for public buckets.
 	PublicBaseUrl *string `protobuf:"bytes,5,opt,name=public_base_url,json=publicBaseUrl,proto3,oneof" json:"public_base_url,omitempty"`
+	// Customer-managed encryption key to use for objects in this bucket, if any.
+	// For GCS this is the CMEK resource name
+	// ("projects/*/locations/*/keyRings/*/cryptoKeys/*"),
+	// and for S3 the SSE-KMS key ARN.
+	KmsKey *string `protobuf:"bytes,6,opt,name=kms_key,json=kmsKey,proto3,oneof" json:"kms_key,omitempty"`
+	// The access the deployment has to the bucket.
+	// Unspecified means read-write.
+	AccessMode    Bucket_AccessMode `protobuf:"varint,7,opt,name=access_mode,json=accessMode,proto3,enum=encore.runtime.v1.Bucket_AccessMode" json:"access_mode,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Bucket) Reset() {
 	*x = Bucket{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[19]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1732,7 +2097,7 @@ func (x *Bucket) String() string {
 func (*Bucket) ProtoMessage() {}
 
 func (x *Bucket) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[19]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1745,7 +2110,7 @@ func (x *Bucket) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Bucket.ProtoReflect.Descriptor instead.
 func (*Bucket) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{19}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *Bucket) GetRid() string {
@@ -1783,6 +2148,20 @@ func (x *Bucket) GetPublicBaseUrl() string {
 	return ""
 }
 
+func (x *Bucket) GetKmsKey() string {
+	if x != nil && x.KmsKey != nil {
+		return *x.KmsKey
+	}
+	return ""
+}
+
+func (x *Bucket) GetAccessMode() Bucket_AccessMode {
+	if x != nil {
+		return x.AccessMode
+	}
+	return Bucket_ACCESS_MODE_UNSPECIFIED
+}
+
 type Gateway struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The unique id for this resource.
@@ -1795,14 +2174,34 @@ type Gateway struct {
 	// The hostnames this gateway accepts requests for.
 	Hostnames []string `protobuf:"bytes,4,rep,name=hostnames,proto3" json:"hostnames,omitempty"`
 	// CORS is the CORS configuration for this gateway.
-	Cors          *Gateway_CORS `protobuf:"bytes,5,opt,name=cors,proto3" json:"cors,omitempty"`
+	Cors *Gateway_CORS `protobuf:"bytes,5,opt,name=cors,proto3" json:"cors,omitempty"`
+	// The default timeout for requests, after which the gateway
+	// responds with 504 Gateway Timeout. If unset requests never time out.
+	RequestTimeout *durationpb.Duration `protobuf:"bytes,6,opt,name=request_timeout,json=requestTimeout,proto3,oneof" json:"request_timeout,omitempty"`
+	// Request timeouts for specific endpoints, overriding request_timeout.
+	RouteTimeouts []*Gateway_RouteTimeout `protobuf:"bytes,7,rep,name=route_timeouts,json=routeTimeouts,proto3" json:"route_timeouts,omitempty"`
+	// All base urls for reaching this gateway, such as when it's fronted
+	// by multiple domains. The first is the canonical base_url.
+	// If empty, base_url is the only base url.
+	BaseUrls []string `protobuf:"bytes,8,rep,name=base_urls,json=baseUrls,proto3" json:"base_urls,omitempty"`
+	// The rate limit for requests to this gateway.
+	// If unset requests are not rate limited.
+	RateLimit *Gateway_RateLimit `protobuf:"bytes,9,opt,name=rate_limit,json=rateLimit,proto3,oneof" json:"rate_limit,omitempty"`
+	// The maximum size of request bodies in bytes, above which the gateway
+	// rejects the request. Zero means unlimited.
+	// If unset the runtime default is used.
+	MaxRequestBodySize *uint64 `protobuf:"varint,10,opt,name=max_request_body_size,json=maxRequestBodySize,proto3,oneof" json:"max_request_body_size,omitempty"`
+	// The deployment variant of the gateway, such as "blue" or "green"
+	// when swapping between two versions of it. Unset if the gateway
+	// isn't deployed in variants.
+	Variant       *string `protobuf:"bytes,11,opt,name=variant,proto3,oneof" json:"variant,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Gateway) Reset() {
 	*x = Gateway{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[20]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1814,7 +2213,7 @@ func (x *Gateway) String() string {
 func (*Gateway) ProtoMessage() {}
 
 func (x *Gateway) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[20]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1827,7 +2226,7 @@ func (x *Gateway) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Gateway.ProtoReflect.Descriptor instead.
 func (*Gateway) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{20}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *Gateway) GetRid() string {
@@ -1865,6 +2264,48 @@ func (x *Gateway) GetCors() *Gateway_CORS {
 	return nil
 }
 
+func (x *Gateway) GetRequestTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.RequestTimeout
+	}
+	return nil
+}
+
+func (x *Gateway) GetRouteTimeouts() []*Gateway_RouteTimeout {
+	if x != nil {
+		return x.RouteTimeouts
+	}
+	return nil
+}
+
+func (x *Gateway) GetBaseUrls() []string {
+	if x != nil {
+		return x.BaseUrls
+	}
+	return nil
+}
+
+func (x *Gateway) GetRateLimit() *Gateway_RateLimit {
+	if x != nil {
+		return x.RateLimit
+	}
+	return nil
+}
+
+func (x *Gateway) GetMaxRequestBodySize() uint64 {
+	if x != nil && x.MaxRequestBodySize != nil {
+		return *x.MaxRequestBodySize
+	}
+	return 0
+}
+
+func (x *Gateway) GetVariant() string {
+	if x != nil && x.Variant != nil {
+		return *x.Variant
+	}
+	return ""
+}
+
 type Infrastructure_Credentials struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ClientCerts   []*ClientCert          `protobuf:"bytes,1,rep,name=client_certs,json=clientCerts,proto3" json:"client_certs,omitempty"`
@@ -1876,7 +2317,7 @@ type Infrastructure_Credentials struct {
 
 func (x *Infrastructure_Credentials) Reset() {
 	*x = Infrastructure_Credentials{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[21]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1888,7 +2329,7 @@ func (x *Infrastructure_Credentials) String() string {
 func (*Infrastructure_Credentials) ProtoMessage() {}
 
 func (x *Infrastructure_Credentials) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[21]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1940,7 +2381,7 @@ type Infrastructure_Resources struct {
 
 func (x *Infrastructure_Resources) Reset() {
 	*x = Infrastructure_Resources{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[22]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1952,7 +2393,7 @@ func (x *Infrastructure_Resources) String() string {
 func (*Infrastructure_Resources) ProtoMessage() {}
 
 func (x *Infrastructure_Resources) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[22]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2027,7 +2468,7 @@ type SecretProvider_GCPSecretManager struct {
 
 func (x *SecretProvider_GCPSecretManager) Reset() {
 	*x = SecretProvider_GCPSecretManager{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[23]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2039,7 +2480,7 @@ func (x *SecretProvider_GCPSecretManager) String() string {
 func (*SecretProvider_GCPSecretManager) ProtoMessage() {}
 
 func (x *SecretProvider_GCPSecretManager) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[23]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2062,6 +2503,62 @@ func (x *SecretProvider_GCPSecretManager) GetProjectId() string {
 	return ""
 }
 
+type SQLRole_CredentialRotation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// How long fetched credentials are valid for. The runtime fetches
+	// fresh credentials and reconnects before they expire.
+	Ttl *durationpb.Duration `protobuf:"bytes,1,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// The URL to fetch fresh credentials from, such as a
+	// Vault dynamic database credentials endpoint.
+	SourceUrl     string `protobuf:"bytes,2,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SQLRole_CredentialRotation) Reset() {
+	*x = SQLRole_CredentialRotation{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SQLRole_CredentialRotation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SQLRole_CredentialRotation) ProtoMessage() {}
+
+func (x *SQLRole_CredentialRotation) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SQLRole_CredentialRotation.ProtoReflect.Descriptor instead.
+func (*SQLRole_CredentialRotation) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{7, 0}
+}
+
+func (x *SQLRole_CredentialRotation) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *SQLRole_CredentialRotation) GetSourceUrl() string {
+	if x != nil {
+		return x.SourceUrl
+	}
+	return ""
+}
+
 type RedisRole_AuthACL struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
@@ -2072,7 +2569,7 @@ type RedisRole_AuthACL struct {
 
 func (x *RedisRole_AuthACL) Reset() {
 	*x = RedisRole_AuthACL{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[24]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2084,7 +2581,7 @@ func (x *RedisRole_AuthACL) String() string {
 func (*RedisRole_AuthACL) ProtoMessage() {}
 
 func (x *RedisRole_AuthACL) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[24]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2097,7 +2594,7 @@ func (x *RedisRole_AuthACL) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RedisRole_AuthACL.ProtoReflect.Descriptor instead.
 func (*RedisRole_AuthACL) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{12, 0}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{13, 0}
 }
 
 func (x *RedisRole_AuthACL) GetUsername() string {
@@ -2122,7 +2619,7 @@ type PubSubCluster_EncoreCloud struct {
 
 func (x *PubSubCluster_EncoreCloud) Reset() {
 	*x = PubSubCluster_EncoreCloud{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[25]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2134,7 +2631,7 @@ func (x *PubSubCluster_EncoreCloud) String() string {
 func (*PubSubCluster_EncoreCloud) ProtoMessage() {}
 
 func (x *PubSubCluster_EncoreCloud) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[25]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2147,7 +2644,7 @@ func (x *PubSubCluster_EncoreCloud) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubSubCluster_EncoreCloud.ProtoReflect.Descriptor instead.
 func (*PubSubCluster_EncoreCloud) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{15, 0}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{16, 0}
 }
 
 type PubSubCluster_AWSSqsSns struct {
@@ -2158,7 +2655,7 @@ type PubSubCluster_AWSSqsSns struct {
 
 func (x *PubSubCluster_AWSSqsSns) Reset() {
 	*x = PubSubCluster_AWSSqsSns{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[26]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2170,7 +2667,7 @@ func (x *PubSubCluster_AWSSqsSns) String() string {
 func (*PubSubCluster_AWSSqsSns) ProtoMessage() {}
 
 func (x *PubSubCluster_AWSSqsSns) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[26]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2183,7 +2680,7 @@ func (x *PubSubCluster_AWSSqsSns) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubSubCluster_AWSSqsSns.ProtoReflect.Descriptor instead.
 func (*PubSubCluster_AWSSqsSns) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{15, 1}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{16, 1}
 }
 
 type PubSubCluster_GCPPubSub struct {
@@ -2194,7 +2691,7 @@ type PubSubCluster_GCPPubSub struct {
 
 func (x *PubSubCluster_GCPPubSub) Reset() {
 	*x = PubSubCluster_GCPPubSub{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[27]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2206,7 +2703,7 @@ func (x *PubSubCluster_GCPPubSub) String() string {
 func (*PubSubCluster_GCPPubSub) ProtoMessage() {}
 
 func (x *PubSubCluster_GCPPubSub) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[27]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2219,7 +2716,7 @@ func (x *PubSubCluster_GCPPubSub) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubSubCluster_GCPPubSub.ProtoReflect.Descriptor instead.
 func (*PubSubCluster_GCPPubSub) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{15, 2}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{16, 2}
 }
 
 type PubSubCluster_NSQ struct {
@@ -2232,7 +2729,7 @@ type PubSubCluster_NSQ struct {
 
 func (x *PubSubCluster_NSQ) Reset() {
 	*x = PubSubCluster_NSQ{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[28]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2244,7 +2741,7 @@ func (x *PubSubCluster_NSQ) String() string {
 func (*PubSubCluster_NSQ) ProtoMessage() {}
 
 func (x *PubSubCluster_NSQ) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[28]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2257,7 +2754,7 @@ func (x *PubSubCluster_NSQ) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubSubCluster_NSQ.ProtoReflect.Descriptor instead.
 func (*PubSubCluster_NSQ) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{15, 3}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{16, 3}
 }
 
 func (x *PubSubCluster_NSQ) GetHosts() []string {
@@ -2276,7 +2773,7 @@ type PubSubCluster_AzureServiceBus struct {
 
 func (x *PubSubCluster_AzureServiceBus) Reset() {
 	*x = PubSubCluster_AzureServiceBus{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[29]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2288,7 +2785,7 @@ func (x *PubSubCluster_AzureServiceBus) String() string {
 func (*PubSubCluster_AzureServiceBus) ProtoMessage() {}
 
 func (x *PubSubCluster_AzureServiceBus) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[29]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2301,7 +2798,7 @@ func (x *PubSubCluster_AzureServiceBus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubSubCluster_AzureServiceBus.ProtoReflect.Descriptor instead.
 func (*PubSubCluster_AzureServiceBus) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{15, 4}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{16, 4}
 }
 
 func (x *PubSubCluster_AzureServiceBus) GetNamespace() string {
@@ -2311,6 +2808,121 @@ func (x *PubSubCluster_AzureServiceBus) GetNamespace() string {
 	return ""
 }
 
+type PubSubCluster_Kafka struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The brokers to connect to. Must be non-empty.
+	Brokers []string `protobuf:"bytes,1,rep,name=brokers,proto3" json:"brokers,omitempty"`
+	// SASL authentication to use, if any.
+	Sasl          *PubSubCluster_Kafka_SASL `protobuf:"bytes,2,opt,name=sasl,proto3,oneof" json:"sasl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PubSubCluster_Kafka) Reset() {
+	*x = PubSubCluster_Kafka{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PubSubCluster_Kafka) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PubSubCluster_Kafka) ProtoMessage() {}
+
+func (x *PubSubCluster_Kafka) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PubSubCluster_Kafka.ProtoReflect.Descriptor instead.
+func (*PubSubCluster_Kafka) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{16, 5}
+}
+
+func (x *PubSubCluster_Kafka) GetBrokers() []string {
+	if x != nil {
+		return x.Brokers
+	}
+	return nil
+}
+
+func (x *PubSubCluster_Kafka) GetSasl() *PubSubCluster_Kafka_SASL {
+	if x != nil {
+		return x.Sasl
+	}
+	return nil
+}
+
+type PubSubCluster_Kafka_SASL struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The SASL mechanism, e.g. "PLAIN" or "SCRAM-SHA-512".
+	Mechanism     string      `protobuf:"bytes,1,opt,name=mechanism,proto3" json:"mechanism,omitempty"`
+	Username      string      `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Password      *SecretData `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PubSubCluster_Kafka_SASL) Reset() {
+	*x = PubSubCluster_Kafka_SASL{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PubSubCluster_Kafka_SASL) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PubSubCluster_Kafka_SASL) ProtoMessage() {}
+
+func (x *PubSubCluster_Kafka_SASL) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PubSubCluster_Kafka_SASL.ProtoReflect.Descriptor instead.
+func (*PubSubCluster_Kafka_SASL) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{16, 5, 0}
+}
+
+func (x *PubSubCluster_Kafka_SASL) GetMechanism() string {
+	if x != nil {
+		return x.Mechanism
+	}
+	return ""
+}
+
+func (x *PubSubCluster_Kafka_SASL) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *PubSubCluster_Kafka_SASL) GetPassword() *SecretData {
+	if x != nil {
+		return x.Password
+	}
+	return nil
+}
+
 type PubSubTopic_GCPConfig struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The GCP project id where the topic exists.
@@ -2321,7 +2933,7 @@ type PubSubTopic_GCPConfig struct {
 
 func (x *PubSubTopic_GCPConfig) Reset() {
 	*x = PubSubTopic_GCPConfig{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[30]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2333,7 +2945,7 @@ func (x *PubSubTopic_GCPConfig) String() string {
 func (*PubSubTopic_GCPConfig) ProtoMessage() {}
 
 func (x *PubSubTopic_GCPConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[30]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2346,7 +2958,7 @@ func (x *PubSubTopic_GCPConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubSubTopic_GCPConfig.ProtoReflect.Descriptor instead.
 func (*PubSubTopic_GCPConfig) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{16, 0}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{17, 0}
 }
 
 func (x *PubSubTopic_GCPConfig) GetProjectId() string {
@@ -2356,6 +2968,152 @@ func (x *PubSubTopic_GCPConfig) GetProjectId() string {
 	return ""
 }
 
+type PubSubTopic_KafkaConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether messages are partitioned by key, using the ordering attribute.
+	// Messages with the same key are delivered in order.
+	KeyedPartitioning bool `protobuf:"varint,1,opt,name=keyed_partitioning,json=keyedPartitioning,proto3" json:"keyed_partitioning,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PubSubTopic_KafkaConfig) Reset() {
+	*x = PubSubTopic_KafkaConfig{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PubSubTopic_KafkaConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PubSubTopic_KafkaConfig) ProtoMessage() {}
+
+func (x *PubSubTopic_KafkaConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PubSubTopic_KafkaConfig.ProtoReflect.Descriptor instead.
+func (*PubSubTopic_KafkaConfig) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{17, 1}
+}
+
+func (x *PubSubTopic_KafkaConfig) GetKeyedPartitioning() bool {
+	if x != nil {
+		return x.KeyedPartitioning
+	}
+	return false
+}
+
+type PubSubSubscription_DeadLetterPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The encore name of the topic to forward undeliverable messages to.
+	TopicEncoreName string `protobuf:"bytes,1,opt,name=topic_encore_name,json=topicEncoreName,proto3" json:"topic_encore_name,omitempty"`
+	// The maximum number of delivery attempts before a message is
+	// forwarded to the dead-letter topic. If zero the provider default is used.
+	MaxDeliveryAttempts int32 `protobuf:"varint,2,opt,name=max_delivery_attempts,json=maxDeliveryAttempts,proto3" json:"max_delivery_attempts,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *PubSubSubscription_DeadLetterPolicy) Reset() {
+	*x = PubSubSubscription_DeadLetterPolicy{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PubSubSubscription_DeadLetterPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PubSubSubscription_DeadLetterPolicy) ProtoMessage() {}
+
+func (x *PubSubSubscription_DeadLetterPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PubSubSubscription_DeadLetterPolicy.ProtoReflect.Descriptor instead.
+func (*PubSubSubscription_DeadLetterPolicy) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{18, 0}
+}
+
+func (x *PubSubSubscription_DeadLetterPolicy) GetTopicEncoreName() string {
+	if x != nil {
+		return x.TopicEncoreName
+	}
+	return ""
+}
+
+func (x *PubSubSubscription_DeadLetterPolicy) GetMaxDeliveryAttempts() int32 {
+	if x != nil {
+		return x.MaxDeliveryAttempts
+	}
+	return 0
+}
+
+type PubSubSubscription_KafkaConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The consumer group the subscription consumes messages as.
+	ConsumerGroup string `protobuf:"bytes,1,opt,name=consumer_group,json=consumerGroup,proto3" json:"consumer_group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PubSubSubscription_KafkaConfig) Reset() {
+	*x = PubSubSubscription_KafkaConfig{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PubSubSubscription_KafkaConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PubSubSubscription_KafkaConfig) ProtoMessage() {}
+
+func (x *PubSubSubscription_KafkaConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PubSubSubscription_KafkaConfig.ProtoReflect.Descriptor instead.
+func (*PubSubSubscription_KafkaConfig) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{18, 1}
+}
+
+func (x *PubSubSubscription_KafkaConfig) GetConsumerGroup() string {
+	if x != nil {
+		return x.ConsumerGroup
+	}
+	return ""
+}
+
 type PubSubSubscription_GCPConfig struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The GCP project id where the subscription exists.
@@ -2366,13 +3124,19 @@ type PubSubSubscription_GCPConfig struct {
 	// The audience to use when validating JWTs delivered over push.
 	// If set, the JWT audience claim must match. If unset, any JWT audience is allowed.
 	PushJwtAudience *string `protobuf:"bytes,3,opt,name=push_jwt_audience,json=pushJwtAudience,proto3,oneof" json:"push_jwt_audience,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// The endpoint messages are pushed to, for push subscriptions.
+	PushEndpoint *string `protobuf:"bytes,4,opt,name=push_endpoint,json=pushEndpoint,proto3,oneof" json:"push_endpoint,omitempty"`
+	// How long to wait for a message to be acknowledged before redelivering it.
+	AckDeadline *durationpb.Duration `protobuf:"bytes,5,opt,name=ack_deadline,json=ackDeadline,proto3,oneof" json:"ack_deadline,omitempty"`
+	// How long to retain unacknowledged messages.
+	MessageRetention *durationpb.Duration `protobuf:"bytes,6,opt,name=message_retention,json=messageRetention,proto3,oneof" json:"message_retention,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *PubSubSubscription_GCPConfig) Reset() {
 	*x = PubSubSubscription_GCPConfig{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[31]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2384,7 +3148,7 @@ func (x *PubSubSubscription_GCPConfig) String() string {
 func (*PubSubSubscription_GCPConfig) ProtoMessage() {}
 
 func (x *PubSubSubscription_GCPConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[31]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2397,7 +3161,7 @@ func (x *PubSubSubscription_GCPConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubSubSubscription_GCPConfig.ProtoReflect.Descriptor instead.
 func (*PubSubSubscription_GCPConfig) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{17, 0}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{18, 2}
 }
 
 func (x *PubSubSubscription_GCPConfig) GetProjectId() string {
@@ -2421,6 +3185,27 @@ func (x *PubSubSubscription_GCPConfig) GetPushJwtAudience() string {
 	return ""
 }
 
+func (x *PubSubSubscription_GCPConfig) GetPushEndpoint() string {
+	if x != nil && x.PushEndpoint != nil {
+		return *x.PushEndpoint
+	}
+	return ""
+}
+
+func (x *PubSubSubscription_GCPConfig) GetAckDeadline() *durationpb.Duration {
+	if x != nil {
+		return x.AckDeadline
+	}
+	return nil
+}
+
+func (x *PubSubSubscription_GCPConfig) GetMessageRetention() *durationpb.Duration {
+	if x != nil {
+		return x.MessageRetention
+	}
+	return nil
+}
+
 type BucketCluster_S3 struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Region to connect to.
@@ -2437,7 +3222,7 @@ type BucketCluster_S3 struct {
 
 func (x *BucketCluster_S3) Reset() {
 	*x = BucketCluster_S3{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[32]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2449,7 +3234,7 @@ func (x *BucketCluster_S3) String() string {
 func (*BucketCluster_S3) ProtoMessage() {}
 
 func (x *BucketCluster_S3) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[32]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2462,7 +3247,7 @@ func (x *BucketCluster_S3) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketCluster_S3.ProtoReflect.Descriptor instead.
 func (*BucketCluster_S3) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{18, 0}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{19, 0}
 }
 
 func (x *BucketCluster_S3) GetRegion() string {
@@ -2508,7 +3293,7 @@ type BucketCluster_GCS struct {
 
 func (x *BucketCluster_GCS) Reset() {
 	*x = BucketCluster_GCS{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[33]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2520,7 +3305,7 @@ func (x *BucketCluster_GCS) String() string {
 func (*BucketCluster_GCS) ProtoMessage() {}
 
 func (x *BucketCluster_GCS) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[33]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2533,7 +3318,7 @@ func (x *BucketCluster_GCS) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BucketCluster_GCS.ProtoReflect.Descriptor instead.
 func (*BucketCluster_GCS) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{18, 1}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{19, 1}
 }
 
 func (x *BucketCluster_GCS) GetEndpoint() string {
@@ -2571,7 +3356,7 @@ type BucketCluster_GCS_LocalSignOptions struct {
 
 func (x *BucketCluster_GCS_LocalSignOptions) Reset() {
 	*x = BucketCluster_GCS_LocalSignOptions{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[34]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2583,7 +3368,7 @@ func (x *BucketCluster_GCS_LocalSignOptions) String() string {
 func (*BucketCluster_GCS_LocalSignOptions) ProtoMessage() {}
 
 func (x *BucketCluster_GCS_LocalSignOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[34]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2596,7 +3381,7 @@ func (x *BucketCluster_GCS_LocalSignOptions) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use BucketCluster_GCS_LocalSignOptions.ProtoReflect.Descriptor instead.
 func (*BucketCluster_GCS_LocalSignOptions) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{18, 1, 0}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{19, 1, 0}
 }
 
 func (x *BucketCluster_GCS_LocalSignOptions) GetBaseUrl() string {
@@ -2620,6 +3405,140 @@ func (x *BucketCluster_GCS_LocalSignOptions) GetPrivateKey() string {
 	return ""
 }
 
+type Gateway_RateLimit struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The number of requests allowed per window.
+	Requests uint32 `protobuf:"varint,1,opt,name=requests,proto3" json:"requests,omitempty"`
+	// The window requests are counted over.
+	Window *durationpb.Duration `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`
+	// The number of requests that may be made at once.
+	Burst uint32 `protobuf:"varint,3,opt,name=burst,proto3" json:"burst,omitempty"`
+	// Whether the limit applies per client IP address
+	// rather than across all clients.
+	PerIp         bool `protobuf:"varint,4,opt,name=per_ip,json=perIp,proto3" json:"per_ip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Gateway_RateLimit) Reset() {
+	*x = Gateway_RateLimit{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Gateway_RateLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Gateway_RateLimit) ProtoMessage() {}
+
+func (x *Gateway_RateLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Gateway_RateLimit.ProtoReflect.Descriptor instead.
+func (*Gateway_RateLimit) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{21, 0}
+}
+
+func (x *Gateway_RateLimit) GetRequests() uint32 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
+}
+
+func (x *Gateway_RateLimit) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *Gateway_RateLimit) GetBurst() uint32 {
+	if x != nil {
+		return x.Burst
+	}
+	return 0
+}
+
+func (x *Gateway_RateLimit) GetPerIp() bool {
+	if x != nil {
+		return x.PerIp
+	}
+	return false
+}
+
+type Gateway_RouteTimeout struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The service and endpoint the timeout applies to.
+	Service       string               `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Endpoint      string               `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Timeout       *durationpb.Duration `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Gateway_RouteTimeout) Reset() {
+	*x = Gateway_RouteTimeout{}
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Gateway_RouteTimeout) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Gateway_RouteTimeout) ProtoMessage() {}
+
+func (x *Gateway_RouteTimeout) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Gateway_RouteTimeout.ProtoReflect.Descriptor instead.
+func (*Gateway_RouteTimeout) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{21, 1}
+}
+
+func (x *Gateway_RouteTimeout) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *Gateway_RouteTimeout) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *Gateway_RouteTimeout) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
 // CORS describes the CORS configuration for a gateway.
 type Gateway_CORS struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -2662,7 +3581,7 @@ type Gateway_CORS struct {
 
 func (x *Gateway_CORS) Reset() {
 	*x = Gateway_CORS{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[35]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2674,7 +3593,7 @@ func (x *Gateway_CORS) String() string {
 func (*Gateway_CORS) ProtoMessage() {}
 
 func (x *Gateway_CORS) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[35]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2687,7 +3606,7 @@ func (x *Gateway_CORS) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Gateway_CORS.ProtoReflect.Descriptor instead.
 func (*Gateway_CORS) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{20, 0}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{21, 2}
 }
 
 func (x *Gateway_CORS) GetDebug() bool {
@@ -2789,7 +3708,7 @@ type Gateway_CORSAllowedOrigins struct {
 
 func (x *Gateway_CORSAllowedOrigins) Reset() {
 	*x = Gateway_CORSAllowedOrigins{}
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[36]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2801,7 +3720,7 @@ func (x *Gateway_CORSAllowedOrigins) String() string {
 func (*Gateway_CORSAllowedOrigins) ProtoMessage() {}
 
 func (x *Gateway_CORSAllowedOrigins) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_infra_proto_msgTypes[36]
+	mi := &file_encore_runtime_v1_infra_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2814,7 +3733,7 @@ func (x *Gateway_CORSAllowedOrigins) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Gateway_CORSAllowedOrigins.ProtoReflect.Descriptor instead.
 func (*Gateway_CORSAllowedOrigins) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{20, 1}
+	return file_encore_runtime_v1_infra_proto_rawDescGZIP(), []int{21, 3}
 }
 
 func (x *Gateway_CORSAllowedOrigins) GetAllowedOrigins() []string {
@@ -2828,7 +3747,7 @@ var File_encore_runtime_v1_infra_proto protoreflect.FileDescriptor
 
 const file_encore_runtime_v1_infra_proto_rawDesc = "" +
 	"\n" +
-	"\x1dencore/runtime/v1/infra.proto\x12\x11encore.runtime.v1\x1a\"encore/runtime/v1/secretdata.proto\"\xe9\x06\n" +
+	"\x1dencore/runtime/v1/infra.proto\x12\x11encore.runtime.v1\x1a\"encore/runtime/v1/secretdata.proto\x1a\x1egoogle/protobuf/duration.proto\"\xe9\x06\n" +
 	"\x0eInfrastructure\x12I\n" +
 	"\tresources\x18\x01 \x01(\v2+.encore.runtime.v1.Infrastructure.ResourcesR\tresources\x12O\n" +
 	"\vcredentials\x18\x02 \x01(\v2-.encore.runtime.v1.Infrastructure.CredentialsR\vcredentials\x1a\xc7\x01\n" +
@@ -2856,35 +3775,56 @@ const file_encore_runtime_v1_infra_proto_rawDesc = "" +
 	"\n" +
 	"project_id\x18\x01 \x01(\tR\tprojectIdB\n" +
 	"\n" +
-	"\bprovider\"\x94\x01\n" +
+	"\bprovider\"\xe5\x01\n" +
 	"\n" +
 	"SQLCluster\x12\x10\n" +
 	"\x03rid\x18\x01 \x01(\tR\x03rid\x126\n" +
 	"\aservers\x18\x02 \x03(\v2\x1c.encore.runtime.v1.SQLServerR\aservers\x12<\n" +
-	"\tdatabases\x18\x03 \x03(\v2\x1e.encore.runtime.v1.SQLDatabaseR\tdatabases\"\xc8\x01\n" +
+	"\tdatabases\x18\x03 \x03(\v2\x1e.encore.runtime.v1.SQLDatabaseR\tdatabases\x12@\n" +
+	"\n" +
+	"ssh_tunnel\x18\x04 \x01(\v2\x1c.encore.runtime.v1.SSHTunnelH\x00R\tsshTunnel\x88\x01\x01B\r\n" +
+	"\v_ssh_tunnel\"\x82\x01\n" +
+	"\tSSHTunnel\x12!\n" +
+	"\fbastion_host\x18\x01 \x01(\tR\vbastionHost\x12\x12\n" +
+	"\x04user\x18\x02 \x01(\tR\x04user\x12>\n" +
+	"\vprivate_key\x18\x03 \x01(\v2\x1d.encore.runtime.v1.SecretDataR\n" +
+	"privateKey\"\xfe\x01\n" +
 	"\tTLSConfig\x12)\n" +
 	"\x0eserver_ca_cert\x18\x01 \x01(\tH\x00R\fserverCaCert\x88\x01\x01\x12I\n" +
 	"!disable_tls_hostname_verification\x18\x02 \x01(\bR\x1edisableTlsHostnameVerification\x122\n" +
-	"\x15disable_ca_validation\x18\x03 \x01(\bR\x13disableCaValidationB\x11\n" +
-	"\x0f_server_ca_cert\"\xb5\x01\n" +
+	"\x15disable_ca_validation\x18\x03 \x01(\bR\x13disableCaValidation\x12$\n" +
+	"\vserver_name\x18\x04 \x01(\tH\x01R\n" +
+	"serverName\x88\x01\x01B\x11\n" +
+	"\x0f_server_ca_certB\x0e\n" +
+	"\f_server_name\"\xa3\x02\n" +
 	"\tSQLServer\x12\x10\n" +
 	"\x03rid\x18\x01 \x01(\tR\x03rid\x12\x12\n" +
 	"\x04host\x18\x02 \x01(\tR\x04host\x121\n" +
 	"\x04kind\x18\x03 \x01(\x0e2\x1d.encore.runtime.v1.ServerKindR\x04kind\x12@\n" +
 	"\n" +
-	"tls_config\x18\x04 \x01(\v2\x1c.encore.runtime.v1.TLSConfigH\x00R\ttlsConfig\x88\x01\x01B\r\n" +
+	"tls_config\x18\x04 \x01(\v2\x1c.encore.runtime.v1.TLSConfigH\x00R\ttlsConfig\x88\x01\x01\x12;\n" +
+	"\x06driver\x18\x05 \x01(\x0e2#.encore.runtime.v1.SQLServer.DriverR\x06driver\"/\n" +
+	"\x06Driver\x12\x13\n" +
+	"\x0fDRIVER_POSTGRES\x10\x00\x12\x10\n" +
+	"\fDRIVER_MYSQL\x10\x01B\r\n" +
 	"\v_tls_config\"c\n" +
 	"\n" +
 	"ClientCert\x12\x10\n" +
 	"\x03rid\x18\x01 \x01(\tR\x03rid\x12\x12\n" +
 	"\x04cert\x18\x02 \x01(\tR\x04cert\x12/\n" +
-	"\x03key\x18\x03 \x01(\v2\x1d.encore.runtime.v1.SecretDataR\x03key\"\xb3\x01\n" +
+	"\x03key\x18\x03 \x01(\v2\x1d.encore.runtime.v1.SecretDataR\x03key\"\xf2\x02\n" +
 	"\aSQLRole\x12\x10\n" +
 	"\x03rid\x18\x01 \x01(\tR\x03rid\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x129\n" +
 	"\bpassword\x18\x03 \x01(\v2\x1d.encore.runtime.v1.SecretDataR\bpassword\x12+\n" +
-	"\x0fclient_cert_rid\x18\x04 \x01(\tH\x00R\rclientCertRid\x88\x01\x01B\x12\n" +
-	"\x10_client_cert_rid\"\xa4\x01\n" +
+	"\x0fclient_cert_rid\x18\x04 \x01(\tH\x00R\rclientCertRid\x88\x01\x01\x12N\n" +
+	"\brotation\x18\x05 \x01(\v2-.encore.runtime.v1.SQLRole.CredentialRotationH\x01R\brotation\x88\x01\x01\x1a`\n" +
+	"\x12CredentialRotation\x12+\n" +
+	"\x03ttl\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x12\x1d\n" +
+	"\n" +
+	"source_url\x18\x02 \x01(\tR\tsourceUrlB\x12\n" +
+	"\x10_client_cert_ridB\v\n" +
+	"\t_rotation\"\xc5\x01\n" +
 	"\vSQLDatabase\x12\x10\n" +
 	"\x03rid\x18\x01 \x01(\tR\x03rid\x12\x1f\n" +
 	"\vencore_name\x18\x02 \x01(\tR\n" +
@@ -2892,13 +3832,22 @@ const file_encore_runtime_v1_infra_proto_rawDesc = "" +
 	"\n" +
 	"cloud_name\x18\x03 \x01(\tR\tcloudName\x12C\n" +
 	"\n" +
-	"conn_pools\x18\x04 \x03(\v2$.encore.runtime.v1.SQLConnectionPoolR\tconnPools\"\xa1\x01\n" +
+	"conn_pools\x18\x04 \x03(\v2$.encore.runtime.v1.SQLConnectionPoolR\tconnPools\x12\x1f\n" +
+	"\vsearch_path\x18\x05 \x03(\tR\n" +
+	"searchPath\"\xd8\x03\n" +
 	"\x11SQLConnectionPool\x12\x1f\n" +
 	"\vis_readonly\x18\x01 \x01(\bR\n" +
 	"isReadonly\x12\x19\n" +
 	"\brole_rid\x18\x02 \x01(\tR\aroleRid\x12'\n" +
 	"\x0fmin_connections\x18\x03 \x01(\x05R\x0eminConnections\x12'\n" +
-	"\x0fmax_connections\x18\x04 \x01(\x05R\x0emaxConnections\"\xb7\x01\n" +
+	"\x0fmax_connections\x18\x04 \x01(\x05R\x0emaxConnections\x12L\n" +
+	"\x12idle_reap_interval\x18\x05 \x01(\v2\x19.google.protobuf.DurationH\x00R\x10idleReapInterval\x88\x01\x01\x12&\n" +
+	"\x0fwarmup_on_start\x18\x06 \x01(\bR\rwarmupOnStart\x12A\n" +
+	"\fmax_lifetime\x18\a \x01(\v2\x19.google.protobuf.DurationH\x01R\vmaxLifetime\x88\x01\x01\x12B\n" +
+	"\rmax_idle_time\x18\b \x01(\v2\x19.google.protobuf.DurationH\x02R\vmaxIdleTime\x88\x01\x01B\x15\n" +
+	"\x13_idle_reap_intervalB\x0f\n" +
+	"\r_max_lifetimeB\x10\n" +
+	"\x0e_max_idle_time\"\xb7\x01\n" +
 	"\fRedisCluster\x12\x10\n" +
 	"\x03rid\x18\x01 \x01(\tR\x03rid\x128\n" +
 	"\aservers\x18\x02 \x03(\v2\x1e.encore.runtime.v1.RedisServerR\aservers\x12>\n" +
@@ -2910,13 +3859,20 @@ const file_encore_runtime_v1_infra_proto_rawDesc = "" +
 	"\x04kind\x18\x03 \x01(\x0e2\x1d.encore.runtime.v1.ServerKindR\x04kind\x12@\n" +
 	"\n" +
 	"tls_config\x18\x04 \x01(\v2\x1c.encore.runtime.v1.TLSConfigH\x00R\ttlsConfig\x88\x01\x01B\r\n" +
-	"\v_tls_config\"\xa3\x01\n" +
+	"\v_tls_config\"\xda\x03\n" +
 	"\x13RedisConnectionPool\x12\x1f\n" +
 	"\vis_readonly\x18\x01 \x01(\bR\n" +
 	"isReadonly\x12\x19\n" +
 	"\brole_rid\x18\x02 \x01(\tR\aroleRid\x12'\n" +
 	"\x0fmin_connections\x18\x03 \x01(\x05R\x0eminConnections\x12'\n" +
-	"\x0fmax_connections\x18\x04 \x01(\x05R\x0emaxConnections\"\xc4\x02\n" +
+	"\x0fmax_connections\x18\x04 \x01(\x05R\x0emaxConnections\x12L\n" +
+	"\x12idle_reap_interval\x18\x05 \x01(\v2\x19.google.protobuf.DurationH\x00R\x10idleReapInterval\x88\x01\x01\x12&\n" +
+	"\x0fwarmup_on_start\x18\x06 \x01(\bR\rwarmupOnStart\x12A\n" +
+	"\fmax_lifetime\x18\a \x01(\v2\x19.google.protobuf.DurationH\x01R\vmaxLifetime\x88\x01\x01\x12B\n" +
+	"\rmax_idle_time\x18\b \x01(\v2\x19.google.protobuf.DurationH\x02R\vmaxIdleTime\x88\x01\x01B\x15\n" +
+	"\x13_idle_reap_intervalB\x0f\n" +
+	"\r_max_lifetimeB\x10\n" +
+	"\x0e_max_idle_time\"\xc4\x02\n" +
 	"\tRedisRole\x12\x10\n" +
 	"\x03rid\x18\x01 \x01(\tR\x03rid\x12+\n" +
 	"\x0fclient_cert_rid\x18\x02 \x01(\tH\x01R\rclientCertRid\x88\x01\x01\x128\n" +
@@ -2943,7 +3899,7 @@ const file_encore_runtime_v1_infra_proto_rawDesc = "" +
 	"\x03rid\x18\x01 \x01(\tR\x03rid\x12\x1f\n" +
 	"\vencore_name\x18\x02 \x01(\tR\n" +
 	"encoreName\x121\n" +
-	"\x04data\x18\x03 \x01(\v2\x1d.encore.runtime.v1.SecretDataR\x04data\"\xf5\x04\n" +
+	"\x04data\x18\x03 \x01(\v2\x1d.encore.runtime.v1.SecretDataR\x04data\"\xa5\a\n" +
 	"\rPubSubCluster\x12\x10\n" +
 	"\x03rid\x18\x01 \x01(\tR\x03rid\x126\n" +
 	"\x06topics\x18\x02 \x03(\v2\x1e.encore.runtime.v1.PubSubTopicR\x06topics\x12K\n" +
@@ -2952,16 +3908,26 @@ const file_encore_runtime_v1_infra_proto_rawDesc = "" +
 	"\x03aws\x18\x06 \x01(\v2*.encore.runtime.v1.PubSubCluster.AWSSqsSnsH\x00R\x03aws\x12>\n" +
 	"\x03gcp\x18\a \x01(\v2*.encore.runtime.v1.PubSubCluster.GCPPubSubH\x00R\x03gcp\x12H\n" +
 	"\x05azure\x18\b \x01(\v20.encore.runtime.v1.PubSubCluster.AzureServiceBusH\x00R\x05azure\x128\n" +
-	"\x03nsq\x18\t \x01(\v2$.encore.runtime.v1.PubSubCluster.NSQH\x00R\x03nsq\x1a\r\n" +
+	"\x03nsq\x18\t \x01(\v2$.encore.runtime.v1.PubSubCluster.NSQH\x00R\x03nsq\x12>\n" +
+	"\x05kafka\x18\n" +
+	" \x01(\v2&.encore.runtime.v1.PubSubCluster.KafkaH\x00R\x05kafka\x1a\r\n" +
 	"\vEncoreCloud\x1a\v\n" +
 	"\tAWSSqsSns\x1a\v\n" +
 	"\tGCPPubSub\x1a\x1b\n" +
 	"\x03NSQ\x12\x14\n" +
 	"\x05hosts\x18\x01 \x03(\tR\x05hosts\x1a/\n" +
 	"\x0fAzureServiceBus\x12\x1c\n" +
-	"\tnamespace\x18\x01 \x01(\tR\tnamespaceB\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x1a\xed\x01\n" +
+	"\x05Kafka\x12\x18\n" +
+	"\abrokers\x18\x01 \x03(\tR\abrokers\x12D\n" +
+	"\x04sasl\x18\x02 \x01(\v2+.encore.runtime.v1.PubSubCluster.Kafka.SASLH\x00R\x04sasl\x88\x01\x01\x1a{\n" +
+	"\x04SASL\x12\x1c\n" +
+	"\tmechanism\x18\x01 \x01(\tR\tmechanism\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x129\n" +
+	"\bpassword\x18\x03 \x01(\v2\x1d.encore.runtime.v1.SecretDataR\bpasswordB\a\n" +
+	"\x05_saslB\n" +
 	"\n" +
-	"\bprovider\"\x8b\x04\n" +
+	"\bprovider\"\x9a\x05\n" +
 	"\vPubSubTopic\x12\x10\n" +
 	"\x03rid\x18\x01 \x01(\tR\x03rid\x12\x1f\n" +
 	"\vencore_name\x18\x02 \x01(\tR\n" +
@@ -2972,37 +3938,56 @@ const file_encore_runtime_v1_infra_proto_rawDesc = "" +
 	"\rordering_attr\x18\x05 \x01(\tH\x01R\forderingAttr\x88\x01\x01\x12I\n" +
 	"\n" +
 	"gcp_config\x18\n" +
-	" \x01(\v2(.encore.runtime.v1.PubSubTopic.GCPConfigH\x00R\tgcpConfig\x1a*\n" +
+	" \x01(\v2(.encore.runtime.v1.PubSubTopic.GCPConfigH\x00R\tgcpConfig\x12O\n" +
+	"\fkafka_config\x18\v \x01(\v2*.encore.runtime.v1.PubSubTopic.KafkaConfigH\x00R\vkafkaConfig\x1a*\n" +
 	"\tGCPConfig\x12\x1d\n" +
 	"\n" +
-	"project_id\x18\x01 \x01(\tR\tprojectId\"\x82\x01\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x1a<\n" +
+	"\vKafkaConfig\x12-\n" +
+	"\x12keyed_partitioning\x18\x01 \x01(\bR\x11keyedPartitioning\"\x82\x01\n" +
 	"\x11DeliveryGuarantee\x12\"\n" +
 	"\x1eDELIVERY_GUARANTEE_UNSPECIFIED\x10\x00\x12$\n" +
 	" DELIVERY_GUARANTEE_AT_LEAST_ONCE\x10\x01\x12#\n" +
 	"\x1fDELIVERY_GUARANTEE_EXACTLY_ONCE\x10\x02B\x11\n" +
 	"\x0fprovider_configB\x10\n" +
-	"\x0e_ordering_attr\"\xb4\x04\n" +
+	"\x0e_ordering_attr\"\x97\t\n" +
 	"\x12PubSubSubscription\x12\x10\n" +
 	"\x03rid\x18\x01 \x01(\tR\x03rid\x12*\n" +
 	"\x11topic_encore_name\x18\x02 \x01(\tR\x0ftopicEncoreName\x128\n" +
 	"\x18subscription_encore_name\x18\x03 \x01(\tR\x16subscriptionEncoreName\x12(\n" +
 	"\x10topic_cloud_name\x18\x04 \x01(\tR\x0etopicCloudName\x126\n" +
 	"\x17subscription_cloud_name\x18\x05 \x01(\tR\x15subscriptionCloudName\x12\x1b\n" +
-	"\tpush_only\x18\x06 \x01(\bR\bpushOnly\x12P\n" +
+	"\tpush_only\x18\x06 \x01(\bR\bpushOnly\x12\\\n" +
+	"\vdead_letter\x18\a \x01(\v26.encore.runtime.v1.PubSubSubscription.DeadLetterPolicyH\x01R\n" +
+	"deadLetter\x88\x01\x01\x12P\n" +
 	"\n" +
 	"gcp_config\x18\n" +
-	" \x01(\v2/.encore.runtime.v1.PubSubSubscription.GCPConfigH\x00R\tgcpConfig\x1a\xc1\x01\n" +
+	" \x01(\v2/.encore.runtime.v1.PubSubSubscription.GCPConfigH\x00R\tgcpConfig\x12V\n" +
+	"\fkafka_config\x18\v \x01(\v21.encore.runtime.v1.PubSubSubscription.KafkaConfigH\x00R\vkafkaConfig\x1ar\n" +
+	"\x10DeadLetterPolicy\x12*\n" +
+	"\x11topic_encore_name\x18\x01 \x01(\tR\x0ftopicEncoreName\x122\n" +
+	"\x15max_delivery_attempts\x18\x02 \x01(\x05R\x13maxDeliveryAttempts\x1a4\n" +
+	"\vKafkaConfig\x12%\n" +
+	"\x0econsumer_group\x18\x01 \x01(\tR\rconsumerGroup\x1a\xb4\x03\n" +
 	"\tGCPConfig\x12\x1d\n" +
 	"\n" +
 	"project_id\x18\x01 \x01(\tR\tprojectId\x125\n" +
 	"\x14push_service_account\x18\x02 \x01(\tH\x00R\x12pushServiceAccount\x88\x01\x01\x12/\n" +
-	"\x11push_jwt_audience\x18\x03 \x01(\tH\x01R\x0fpushJwtAudience\x88\x01\x01B\x17\n" +
+	"\x11push_jwt_audience\x18\x03 \x01(\tH\x01R\x0fpushJwtAudience\x88\x01\x01\x12(\n" +
+	"\rpush_endpoint\x18\x04 \x01(\tH\x02R\fpushEndpoint\x88\x01\x01\x12A\n" +
+	"\fack_deadline\x18\x05 \x01(\v2\x19.google.protobuf.DurationH\x03R\vackDeadline\x88\x01\x01\x12K\n" +
+	"\x11message_retention\x18\x06 \x01(\v2\x19.google.protobuf.DurationH\x04R\x10messageRetention\x88\x01\x01B\x17\n" +
 	"\x15_push_service_accountB\x14\n" +
-	"\x12_push_jwt_audienceB\x11\n" +
-	"\x0fprovider_config\"\xec\x05\n" +
+	"\x12_push_jwt_audienceB\x10\n" +
+	"\x0e_push_endpointB\x0f\n" +
+	"\r_ack_deadlineB\x14\n" +
+	"\x12_message_retentionB\x11\n" +
+	"\x0fprovider_configB\x0e\n" +
+	"\f_dead_letter\"\xdc\x06\n" +
 	"\rBucketCluster\x12\x10\n" +
 	"\x03rid\x18\x01 \x01(\tR\x03rid\x123\n" +
-	"\abuckets\x18\x02 \x03(\v2\x19.encore.runtime.v1.BucketR\abuckets\x125\n" +
+	"\abuckets\x18\x02 \x03(\v2\x19.encore.runtime.v1.BucketR\abuckets\x12S\n" +
+	"\x16default_signed_url_ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationH\x01R\x13defaultSignedUrlTtl\x88\x01\x01\x125\n" +
 	"\x02s3\x18\n" +
 	" \x01(\v2#.encore.runtime.v1.BucketCluster.S3H\x00R\x02s3\x128\n" +
 	"\x03gcs\x18\v \x01(\v2$.encore.runtime.v1.BucketCluster.GCSH\x00R\x03gcs\x1a\xeb\x01\n" +
@@ -3027,7 +4012,8 @@ const file_encore_runtime_v1_infra_proto_rawDesc = "" +
 	"\t_endpointB\r\n" +
 	"\v_local_signB\n" +
 	"\n" +
-	"\bprovider\"\xce\x01\n" +
+	"\bproviderB\x19\n" +
+	"\x17_default_signed_url_ttl\"\xa1\x03\n" +
 	"\x06Bucket\x12\x10\n" +
 	"\x03rid\x18\x01 \x01(\tR\x03rid\x12\x1f\n" +
 	"\vencore_name\x18\x02 \x01(\tR\n" +
@@ -3036,16 +4022,43 @@ const file_encore_runtime_v1_infra_proto_rawDesc = "" +
 	"cloud_name\x18\x03 \x01(\tR\tcloudName\x12\"\n" +
 	"\n" +
 	"key_prefix\x18\x04 \x01(\tH\x00R\tkeyPrefix\x88\x01\x01\x12+\n" +
-	"\x0fpublic_base_url\x18\x05 \x01(\tH\x01R\rpublicBaseUrl\x88\x01\x01B\r\n" +
+	"\x0fpublic_base_url\x18\x05 \x01(\tH\x01R\rpublicBaseUrl\x88\x01\x01\x12\x1c\n" +
+	"\akms_key\x18\x06 \x01(\tH\x02R\x06kmsKey\x88\x01\x01\x12E\n" +
+	"\vaccess_mode\x18\a \x01(\x0e2$.encore.runtime.v1.Bucket.AccessModeR\n" +
+	"accessMode\"`\n" +
+	"\n" +
+	"AccessMode\x12\x1b\n" +
+	"\x17ACCESS_MODE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15ACCESS_MODE_READ_ONLY\x10\x01\x12\x1a\n" +
+	"\x16ACCESS_MODE_READ_WRITE\x10\x02B\r\n" +
 	"\v_key_prefixB\x12\n" +
-	"\x10_public_base_url\"\xb9\x06\n" +
+	"\x10_public_base_urlB\n" +
+	"\n" +
+	"\b_kms_key\"\xde\v\n" +
 	"\aGateway\x12\x10\n" +
 	"\x03rid\x18\x01 \x01(\tR\x03rid\x12\x1f\n" +
 	"\vencore_name\x18\x02 \x01(\tR\n" +
 	"encoreName\x12\x19\n" +
 	"\bbase_url\x18\x03 \x01(\tR\abaseUrl\x12\x1c\n" +
 	"\thostnames\x18\x04 \x03(\tR\thostnames\x123\n" +
-	"\x04cors\x18\x05 \x01(\v2\x1f.encore.runtime.v1.Gateway.CORSR\x04cors\x1a\xcd\x04\n" +
+	"\x04cors\x18\x05 \x01(\v2\x1f.encore.runtime.v1.Gateway.CORSR\x04cors\x12G\n" +
+	"\x0frequest_timeout\x18\x06 \x01(\v2\x19.google.protobuf.DurationH\x00R\x0erequestTimeout\x88\x01\x01\x12N\n" +
+	"\x0eroute_timeouts\x18\a \x03(\v2'.encore.runtime.v1.Gateway.RouteTimeoutR\rrouteTimeouts\x12\x1b\n" +
+	"\tbase_urls\x18\b \x03(\tR\bbaseUrls\x12H\n" +
+	"\n" +
+	"rate_limit\x18\t \x01(\v2$.encore.runtime.v1.Gateway.RateLimitH\x01R\trateLimit\x88\x01\x01\x126\n" +
+	"\x15max_request_body_size\x18\n" +
+	" \x01(\x04H\x02R\x12maxRequestBodySize\x88\x01\x01\x12\x1d\n" +
+	"\avariant\x18\v \x01(\tH\x03R\avariant\x88\x01\x01\x1a\x87\x01\n" +
+	"\tRateLimit\x12\x1a\n" +
+	"\brequests\x18\x01 \x01(\rR\brequests\x121\n" +
+	"\x06window\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x06window\x12\x14\n" +
+	"\x05burst\x18\x03 \x01(\rR\x05burst\x12\x15\n" +
+	"\x06per_ip\x18\x04 \x01(\bR\x05perIp\x1ay\n" +
+	"\fRouteTimeout\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12\x1a\n" +
+	"\bendpoint\x18\x02 \x01(\tR\bendpoint\x123\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1a\xcd\x04\n" +
 	"\x04CORS\x12\x14\n" +
 	"\x05debug\x18\x01 \x01(\bR\x05debug\x12/\n" +
 	"\x13disable_credentials\x18\x02 \x01(\bR\x12disableCredentials\x12X\n" +
@@ -3057,7 +4070,12 @@ const file_encore_runtime_v1_infra_proto_rawDesc = "" +
 	"\x1callow_private_network_access\x18\b \x01(\bR\x19allowPrivateNetworkAccessB\"\n" +
 	" allowed_origins_with_credentials\x1a=\n" +
 	"\x12CORSAllowedOrigins\x12'\n" +
-	"\x0fallowed_origins\x18\x01 \x03(\tR\x0eallowedOrigins*}\n" +
+	"\x0fallowed_origins\x18\x01 \x03(\tR\x0eallowedOriginsB\x12\n" +
+	"\x10_request_timeoutB\r\n" +
+	"\v_rate_limitB\x18\n" +
+	"\x16_max_request_body_sizeB\n" +
+	"\n" +
+	"\b_variant*}\n" +
 	"\n" +
 	"ServerKind\x12\x1b\n" +
 	"\x17SERVER_KIND_UNSPECIFIED\x10\x00\x12\x17\n" +
@@ -3077,103 +4095,141 @@ func file_encore_runtime_v1_infra_proto_rawDescGZIP() []byte {
 	return file_encore_runtime_v1_infra_proto_rawDescData
 }
 
-var file_encore_runtime_v1_infra_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_encore_runtime_v1_infra_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
+var file_encore_runtime_v1_infra_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_encore_runtime_v1_infra_proto_msgTypes = make([]protoimpl.MessageInfo, 46)
 var file_encore_runtime_v1_infra_proto_goTypes = []any{
-	(ServerKind)(0),                            // 0: encore.runtime.v1.ServerKind
-	(PubSubTopic_DeliveryGuarantee)(0),         // 1: encore.runtime.v1.PubSubTopic.DeliveryGuarantee
-	(*Infrastructure)(nil),                     // 2: encore.runtime.v1.Infrastructure
-	(*SecretProvider)(nil),                     // 3: encore.runtime.v1.SecretProvider
-	(*SQLCluster)(nil),                         // 4: encore.runtime.v1.SQLCluster
-	(*TLSConfig)(nil),                          // 5: encore.runtime.v1.TLSConfig
-	(*SQLServer)(nil),                          // 6: encore.runtime.v1.SQLServer
-	(*ClientCert)(nil),                         // 7: encore.runtime.v1.ClientCert
-	(*SQLRole)(nil),                            // 8: encore.runtime.v1.SQLRole
-	(*SQLDatabase)(nil),                        // 9: encore.runtime.v1.SQLDatabase
-	(*SQLConnectionPool)(nil),                  // 10: encore.runtime.v1.SQLConnectionPool
-	(*RedisCluster)(nil),                       // 11: encore.runtime.v1.RedisCluster
-	(*RedisServer)(nil),                        // 12: encore.runtime.v1.RedisServer
-	(*RedisConnectionPool)(nil),                // 13: encore.runtime.v1.RedisConnectionPool
-	(*RedisRole)(nil),                          // 14: encore.runtime.v1.RedisRole
-	(*RedisDatabase)(nil),                      // 15: encore.runtime.v1.RedisDatabase
-	(*AppSecret)(nil),                          // 16: encore.runtime.v1.AppSecret
-	(*PubSubCluster)(nil),                      // 17: encore.runtime.v1.PubSubCluster
-	(*PubSubTopic)(nil),                        // 18: encore.runtime.v1.PubSubTopic
-	(*PubSubSubscription)(nil),                 // 19: encore.runtime.v1.PubSubSubscription
-	(*BucketCluster)(nil),                      // 20: encore.runtime.v1.BucketCluster
-	(*Bucket)(nil),                             // 21: encore.runtime.v1.Bucket
-	(*Gateway)(nil),                            // 22: encore.runtime.v1.Gateway
-	(*Infrastructure_Credentials)(nil),         // 23: encore.runtime.v1.Infrastructure.Credentials
-	(*Infrastructure_Resources)(nil),           // 24: encore.runtime.v1.Infrastructure.Resources
-	(*SecretProvider_GCPSecretManager)(nil),    // 25: encore.runtime.v1.SecretProvider.GCPSecretManager
-	(*RedisRole_AuthACL)(nil),                  // 26: encore.runtime.v1.RedisRole.AuthACL
-	(*PubSubCluster_EncoreCloud)(nil),          // 27: encore.runtime.v1.PubSubCluster.EncoreCloud
-	(*PubSubCluster_AWSSqsSns)(nil),            // 28: encore.runtime.v1.PubSubCluster.AWSSqsSns
-	(*PubSubCluster_GCPPubSub)(nil),            // 29: encore.runtime.v1.PubSubCluster.GCPPubSub
-	(*PubSubCluster_NSQ)(nil),                  // 30: encore.runtime.v1.PubSubCluster.NSQ
-	(*PubSubCluster_AzureServiceBus)(nil),      // 31: encore.runtime.v1.PubSubCluster.AzureServiceBus
-	(*PubSubTopic_GCPConfig)(nil),              // 32: encore.runtime.v1.PubSubTopic.GCPConfig
-	(*PubSubSubscription_GCPConfig)(nil),       // 33: encore.runtime.v1.PubSubSubscription.GCPConfig
-	(*BucketCluster_S3)(nil),                   // 34: encore.runtime.v1.BucketCluster.S3
-	(*BucketCluster_GCS)(nil),                  // 35: encore.runtime.v1.BucketCluster.GCS
-	(*BucketCluster_GCS_LocalSignOptions)(nil), // 36: encore.runtime.v1.BucketCluster.GCS.LocalSignOptions
-	(*Gateway_CORS)(nil),                       // 37: encore.runtime.v1.Gateway.CORS
-	(*Gateway_CORSAllowedOrigins)(nil),         // 38: encore.runtime.v1.Gateway.CORSAllowedOrigins
-	(*SecretData)(nil),                         // 39: encore.runtime.v1.SecretData
+	(ServerKind)(0),                             // 0: encore.runtime.v1.ServerKind
+	(SQLServer_Driver)(0),                       // 1: encore.runtime.v1.SQLServer.Driver
+	(PubSubTopic_DeliveryGuarantee)(0),          // 2: encore.runtime.v1.PubSubTopic.DeliveryGuarantee
+	(Bucket_AccessMode)(0),                      // 3: encore.runtime.v1.Bucket.AccessMode
+	(*Infrastructure)(nil),                      // 4: encore.runtime.v1.Infrastructure
+	(*SecretProvider)(nil),                      // 5: encore.runtime.v1.SecretProvider
+	(*SQLCluster)(nil),                          // 6: encore.runtime.v1.SQLCluster
+	(*SSHTunnel)(nil),                           // 7: encore.runtime.v1.SSHTunnel
+	(*TLSConfig)(nil),                           // 8: encore.runtime.v1.TLSConfig
+	(*SQLServer)(nil),                           // 9: encore.runtime.v1.SQLServer
+	(*ClientCert)(nil),                          // 10: encore.runtime.v1.ClientCert
+	(*SQLRole)(nil),                             // 11: encore.runtime.v1.SQLRole
+	(*SQLDatabase)(nil),                         // 12: encore.runtime.v1.SQLDatabase
+	(*SQLConnectionPool)(nil),                   // 13: encore.runtime.v1.SQLConnectionPool
+	(*RedisCluster)(nil),                        // 14: encore.runtime.v1.RedisCluster
+	(*RedisServer)(nil),                         // 15: encore.runtime.v1.RedisServer
+	(*RedisConnectionPool)(nil),                 // 16: encore.runtime.v1.RedisConnectionPool
+	(*RedisRole)(nil),                           // 17: encore.runtime.v1.RedisRole
+	(*RedisDatabase)(nil),                       // 18: encore.runtime.v1.RedisDatabase
+	(*AppSecret)(nil),                           // 19: encore.runtime.v1.AppSecret
+	(*PubSubCluster)(nil),                       // 20: encore.runtime.v1.PubSubCluster
+	(*PubSubTopic)(nil),                         // 21: encore.runtime.v1.PubSubTopic
+	(*PubSubSubscription)(nil),                  // 22: encore.runtime.v1.PubSubSubscription
+	(*BucketCluster)(nil),                       // 23: encore.runtime.v1.BucketCluster
+	(*Bucket)(nil),                              // 24: encore.runtime.v1.Bucket
+	(*Gateway)(nil),                             // 25: encore.runtime.v1.Gateway
+	(*Infrastructure_Credentials)(nil),          // 26: encore.runtime.v1.Infrastructure.Credentials
+	(*Infrastructure_Resources)(nil),            // 27: encore.runtime.v1.Infrastructure.Resources
+	(*SecretProvider_GCPSecretManager)(nil),     // 28: encore.runtime.v1.SecretProvider.GCPSecretManager
+	(*SQLRole_CredentialRotation)(nil),          // 29: encore.runtime.v1.SQLRole.CredentialRotation
+	(*RedisRole_AuthACL)(nil),                   // 30: encore.runtime.v1.RedisRole.AuthACL
+	(*PubSubCluster_EncoreCloud)(nil),           // 31: encore.runtime.v1.PubSubCluster.EncoreCloud
+	(*PubSubCluster_AWSSqsSns)(nil),             // 32: encore.runtime.v1.PubSubCluster.AWSSqsSns
+	(*PubSubCluster_GCPPubSub)(nil),             // 33: encore.runtime.v1.PubSubCluster.GCPPubSub
+	(*PubSubCluster_NSQ)(nil),                   // 34: encore.runtime.v1.PubSubCluster.NSQ
+	(*PubSubCluster_AzureServiceBus)(nil),       // 35: encore.runtime.v1.PubSubCluster.AzureServiceBus
+	(*PubSubCluster_Kafka)(nil),                 // 36: encore.runtime.v1.PubSubCluster.Kafka
+	(*PubSubCluster_Kafka_SASL)(nil),            // 37: encore.runtime.v1.PubSubCluster.Kafka.SASL
+	(*PubSubTopic_GCPConfig)(nil),               // 38: encore.runtime.v1.PubSubTopic.GCPConfig
+	(*PubSubTopic_KafkaConfig)(nil),             // 39: encore.runtime.v1.PubSubTopic.KafkaConfig
+	(*PubSubSubscription_DeadLetterPolicy)(nil), // 40: encore.runtime.v1.PubSubSubscription.DeadLetterPolicy
+	(*PubSubSubscription_KafkaConfig)(nil),      // 41: encore.runtime.v1.PubSubSubscription.KafkaConfig
+	(*PubSubSubscription_GCPConfig)(nil),        // 42: encore.runtime.v1.PubSubSubscription.GCPConfig
+	(*BucketCluster_S3)(nil),                    // 43: encore.runtime.v1.BucketCluster.S3
+	(*BucketCluster_GCS)(nil),                   // 44: encore.runtime.v1.BucketCluster.GCS
+	(*BucketCluster_GCS_LocalSignOptions)(nil),  // 45: encore.runtime.v1.BucketCluster.GCS.LocalSignOptions
+	(*Gateway_RateLimit)(nil),                   // 46: encore.runtime.v1.Gateway.RateLimit
+	(*Gateway_RouteTimeout)(nil),                // 47: encore.runtime.v1.Gateway.RouteTimeout
+	(*Gateway_CORS)(nil),                        // 48: encore.runtime.v1.Gateway.CORS
+	(*Gateway_CORSAllowedOrigins)(nil),          // 49: encore.runtime.v1.Gateway.CORSAllowedOrigins
+	(*SecretData)(nil),                          // 50: encore.runtime.v1.SecretData
+	(*durationpb.Duration)(nil),                 // 51: google.protobuf.Duration
 }
 var file_encore_runtime_v1_infra_proto_depIdxs = []int32{
-	24, // 0: encore.runtime.v1.Infrastructure.resources:type_name -> encore.runtime.v1.Infrastructure.Resources
-	23, // 1: encore.runtime.v1.Infrastructure.credentials:type_name -> encore.runtime.v1.Infrastructure.Credentials
-	25, // 2: encore.runtime.v1.SecretProvider.gcp_sm:type_name -> encore.runtime.v1.SecretProvider.GCPSecretManager
-	6,  // 3: encore.runtime.v1.SQLCluster.servers:type_name -> encore.runtime.v1.SQLServer
-	9,  // 4: encore.runtime.v1.SQLCluster.databases:type_name -> encore.runtime.v1.SQLDatabase
-	0,  // 5: encore.runtime.v1.SQLServer.kind:type_name -> encore.runtime.v1.ServerKind
-	5,  // 6: encore.runtime.v1.SQLServer.tls_config:type_name -> encore.runtime.v1.TLSConfig
-	39, // 7: encore.runtime.v1.ClientCert.key:type_name -> encore.runtime.v1.SecretData
-	39, // 8: encore.runtime.v1.SQLRole.password:type_name -> encore.runtime.v1.SecretData
-	10, // 9: encore.runtime.v1.SQLDatabase.conn_pools:type_name -> encore.runtime.v1.SQLConnectionPool
-	12, // 10: encore.runtime.v1.RedisCluster.servers:type_name -> encore.runtime.v1.RedisServer
-	15, // 11: encore.runtime.v1.RedisCluster.databases:type_name -> encore.runtime.v1.RedisDatabase
-	0,  // 12: encore.runtime.v1.RedisServer.kind:type_name -> encore.runtime.v1.ServerKind
-	5,  // 13: encore.runtime.v1.RedisServer.tls_config:type_name -> encore.runtime.v1.TLSConfig
-	26, // 14: encore.runtime.v1.RedisRole.acl:type_name -> encore.runtime.v1.RedisRole.AuthACL
-	39, // 15: encore.runtime.v1.RedisRole.auth_string:type_name -> encore.runtime.v1.SecretData
-	13, // 16: encore.runtime.v1.RedisDatabase.conn_pools:type_name -> encore.runtime.v1.RedisConnectionPool
-	39, // 17: encore.runtime.v1.AppSecret.data:type_name -> encore.runtime.v1.SecretData
-	18, // 18: encore.runtime.v1.PubSubCluster.topics:type_name -> encore.runtime.v1.PubSubTopic
-	19, // 19: encore.runtime.v1.PubSubCluster.subscriptions:type_name -> encore.runtime.v1.PubSubSubscription
-	27, // 20: encore.runtime.v1.PubSubCluster.encore:type_name -> encore.runtime.v1.PubSubCluster.EncoreCloud
-	28, // 21: encore.runtime.v1.PubSubCluster.aws:type_name -> encore.runtime.v1.PubSubCluster.AWSSqsSns
-	29, // 22: encore.runtime.v1.PubSubCluster.gcp:type_name -> encore.runtime.v1.PubSubCluster.GCPPubSub
-	31, // 23: encore.runtime.v1.PubSubCluster.azure:type_name -> encore.runtime.v1.PubSubCluster.AzureServiceBus
-	30, // 24: encore.runtime.v1.PubSubCluster.nsq:type_name -> encore.runtime.v1.PubSubCluster.NSQ
-	1,  // 25: encore.runtime.v1.PubSubTopic.delivery_guarantee:type_name -> encore.runtime.v1.PubSubTopic.DeliveryGuarantee
-	32, // 26: encore.runtime.v1.PubSubTopic.gcp_config:type_name -> encore.runtime.v1.PubSubTopic.GCPConfig
-	33, // 27: encore.runtime.v1.PubSubSubscription.gcp_config:type_name -> encore.runtime.v1.PubSubSubscription.GCPConfig
-	21, // 28: encore.runtime.v1.BucketCluster.buckets:type_name -> encore.runtime.v1.Bucket
-	34, // 29: encore.runtime.v1.BucketCluster.s3:type_name -> encore.runtime.v1.BucketCluster.S3
-	35, // 30: encore.runtime.v1.BucketCluster.gcs:type_name -> encore.runtime.v1.BucketCluster.GCS
-	37, // 31: encore.runtime.v1.Gateway.cors:type_name -> encore.runtime.v1.Gateway.CORS
-	7,  // 32: encore.runtime.v1.Infrastructure.Credentials.client_certs:type_name -> encore.runtime.v1.ClientCert
-	8,  // 33: encore.runtime.v1.Infrastructure.Credentials.sql_roles:type_name -> encore.runtime.v1.SQLRole
-	14, // 34: encore.runtime.v1.Infrastructure.Credentials.redis_roles:type_name -> encore.runtime.v1.RedisRole
-	22, // 35: encore.runtime.v1.Infrastructure.Resources.gateways:type_name -> encore.runtime.v1.Gateway
-	4,  // 36: encore.runtime.v1.Infrastructure.Resources.sql_clusters:type_name -> encore.runtime.v1.SQLCluster
-	17, // 37: encore.runtime.v1.Infrastructure.Resources.pubsub_clusters:type_name -> encore.runtime.v1.PubSubCluster
-	11, // 38: encore.runtime.v1.Infrastructure.Resources.redis_clusters:type_name -> encore.runtime.v1.RedisCluster
-	16, // 39: encore.runtime.v1.Infrastructure.Resources.app_secrets:type_name -> encore.runtime.v1.AppSecret
-	20, // 40: encore.runtime.v1.Infrastructure.Resources.bucket_clusters:type_name -> encore.runtime.v1.BucketCluster
-	3,  // 41: encore.runtime.v1.Infrastructure.Resources.secret_providers:type_name -> encore.runtime.v1.SecretProvider
-	39, // 42: encore.runtime.v1.RedisRole.AuthACL.password:type_name -> encore.runtime.v1.SecretData
-	39, // 43: encore.runtime.v1.BucketCluster.S3.secret_access_key:type_name -> encore.runtime.v1.SecretData
-	36, // 44: encore.runtime.v1.BucketCluster.GCS.local_sign:type_name -> encore.runtime.v1.BucketCluster.GCS.LocalSignOptions
-	38, // 45: encore.runtime.v1.Gateway.CORS.allowed_origins:type_name -> encore.runtime.v1.Gateway.CORSAllowedOrigins
-	38, // 46: encore.runtime.v1.Gateway.CORS.allowed_origins_without_credentials:type_name -> encore.runtime.v1.Gateway.CORSAllowedOrigins
-	47, // [47:47] is the sub-list for method output_type
-	47, // [47:47] is the sub-list for method input_type
-	47, // [47:47] is the sub-list for extension type_name
-	47, // [47:47] is the sub-list for extension extendee
-	0,  // [0:47] is the sub-list for field type_name
+	27, // 0: encore.runtime.v1.Infrastructure.resources:type_name -> encore.runtime.v1.Infrastructure.Resources
+	26, // 1: encore.runtime.v1.Infrastructure.credentials:type_name -> encore.runtime.v1.Infrastructure.Credentials
+	28, // 2: encore.runtime.v1.SecretProvider.gcp_sm:type_name -> encore.runtime.v1.SecretProvider.GCPSecretManager
+	9,  // 3: encore.runtime.v1.SQLCluster.servers:type_name -> encore.runtime.v1.SQLServer
+	12, // 4: encore.runtime.v1.SQLCluster.databases:type_name -> encore.runtime.v1.SQLDatabase
+	7,  // 5: encore.runtime.v1.SQLCluster.ssh_tunnel:type_name -> encore.runtime.v1.SSHTunnel
+	50, // 6: encore.runtime.v1.SSHTunnel.private_key:type_name -> encore.runtime.v1.SecretData
+	0,  // 7: encore.runtime.v1.SQLServer.kind:type_name -> encore.runtime.v1.ServerKind
+	8,  // 8: encore.runtime.v1.SQLServer.tls_config:type_name -> encore.runtime.v1.TLSConfig
+	1,  // 9: encore.runtime.v1.SQLServer.driver:type_name -> encore.runtime.v1.SQLServer.Driver
+	50, // 10: encore.runtime.v1.ClientCert.key:type_name -> encore.runtime.v1.SecretData
+	50, // 11: encore.runtime.v1.SQLRole.password:type_name -> encore.runtime.v1.SecretData
+	29, // 12: encore.runtime.v1.SQLRole.rotation:type_name -> encore.runtime.v1.SQLRole.CredentialRotation
+	13, // 13: encore.runtime.v1.SQLDatabase.conn_pools:type_name -> encore.runtime.v1.SQLConnectionPool
+	51, // 14: encore.runtime.v1.SQLConnectionPool.idle_reap_interval:type_name -> google.protobuf.Duration
+	51, // 15: encore.runtime.v1.SQLConnectionPool.max_lifetime:type_name -> google.protobuf.Duration
+	51, // 16: encore.runtime.v1.SQLConnectionPool.max_idle_time:type_name -> google.protobuf.Duration
+	15, // 17: encore.runtime.v1.RedisCluster.servers:type_name -> encore.runtime.v1.RedisServer
+	18, // 18: encore.runtime.v1.RedisCluster.databases:type_name -> encore.runtime.v1.RedisDatabase
+	0,  // 19: encore.runtime.v1.RedisServer.kind:type_name -> encore.runtime.v1.ServerKind
+	8,  // 20: encore.runtime.v1.RedisServer.tls_config:type_name -> encore.runtime.v1.TLSConfig
+	51, // 21: encore.runtime.v1.RedisConnectionPool.idle_reap_interval:type_name -> google.protobuf.Duration
+	51, // 22: encore.runtime.v1.RedisConnectionPool.max_lifetime:type_name -> google.protobuf.Duration
+	51, // 23: encore.runtime.v1.RedisConnectionPool.max_idle_time:type_name -> google.protobuf.Duration
+	30, // 24: encore.runtime.v1.RedisRole.acl:type_name -> encore.runtime.v1.RedisRole.AuthACL
+	50, // 25: encore.runtime.v1.RedisRole.auth_string:type_name -> encore.runtime.v1.SecretData
+	16, // 26: encore.runtime.v1.RedisDatabase.conn_pools:type_name -> encore.runtime.v1.RedisConnectionPool
+	50, // 27: encore.runtime.v1.AppSecret.data:type_name -> encore.runtime.v1.SecretData
+	21, // 28: encore.runtime.v1.PubSubCluster.topics:type_name -> encore.runtime.v1.PubSubTopic
+	22, // 29: encore.runtime.v1.PubSubCluster.subscriptions:type_name -> encore.runtime.v1.PubSubSubscription
+	31, // 30: encore.runtime.v1.PubSubCluster.encore:type_name -> encore.runtime.v1.PubSubCluster.EncoreCloud
+	32, // 31: encore.runtime.v1.PubSubCluster.aws:type_name -> encore.runtime.v1.PubSubCluster.AWSSqsSns
+	33, // 32: encore.runtime.v1.PubSubCluster.gcp:type_name -> encore.runtime.v1.PubSubCluster.GCPPubSub
+	35, // 33: encore.runtime.v1.PubSubCluster.azure:type_name -> encore.runtime.v1.PubSubCluster.AzureServiceBus
+	34, // 34: encore.runtime.v1.PubSubCluster.nsq:type_name -> encore.runtime.v1.PubSubCluster.NSQ
+	36, // 35: encore.runtime.v1.PubSubCluster.kafka:type_name -> encore.runtime.v1.PubSubCluster.Kafka
+	2,  // 36: encore.runtime.v1.PubSubTopic.delivery_guarantee:type_name -> encore.runtime.v1.PubSubTopic.DeliveryGuarantee
+	38, // 37: encore.runtime.v1.PubSubTopic.gcp_config:type_name -> encore.runtime.v1.PubSubTopic.GCPConfig
+	39, // 38: encore.runtime.v1.PubSubTopic.kafka_config:type_name -> encore.runtime.v1.PubSubTopic.KafkaConfig
+	40, // 39: encore.runtime.v1.PubSubSubscription.dead_letter:type_name -> encore.runtime.v1.PubSubSubscription.DeadLetterPolicy
+	42, // 40: encore.runtime.v1.PubSubSubscription.gcp_config:type_name -> encore.runtime.v1.PubSubSubscription.GCPConfig
+	41, // 41: encore.runtime.v1.PubSubSubscription.kafka_config:type_name -> encore.runtime.v1.PubSubSubscription.KafkaConfig
+	24, // 42: encore.runtime.v1.BucketCluster.buckets:type_name -> encore.runtime.v1.Bucket
+	51, // 43: encore.runtime.v1.BucketCluster.default_signed_url_ttl:type_name -> google.protobuf.Duration
+	43, // 44: encore.runtime.v1.BucketCluster.s3:type_name -> encore.runtime.v1.BucketCluster.S3
+	44, // 45: encore.runtime.v1.BucketCluster.gcs:type_name -> encore.runtime.v1.BucketCluster.GCS
+	3,  // 46: encore.runtime.v1.Bucket.access_mode:type_name -> encore.runtime.v1.Bucket.AccessMode
+	48, // 47: encore.runtime.v1.Gateway.cors:type_name -> encore.runtime.v1.Gateway.CORS
+	51, // 48: encore.runtime.v1.Gateway.request_timeout:type_name -> google.protobuf.Duration
+	47, // 49: encore.runtime.v1.Gateway.route_timeouts:type_name -> encore.runtime.v1.Gateway.RouteTimeout
+	46, // 50: encore.runtime.v1.Gateway.rate_limit:type_name -> encore.runtime.v1.Gateway.RateLimit
+	10, // 51: encore.runtime.v1.Infrastructure.Credentials.client_certs:type_name -> encore.runtime.v1.ClientCert
+	11, // 52: encore.runtime.v1.Infrastructure.Credentials.sql_roles:type_name -> encore.runtime.v1.SQLRole
+	17, // 53: encore.runtime.v1.Infrastructure.Credentials.redis_roles:type_name -> encore.runtime.v1.RedisRole
+	25, // 54: encore.runtime.v1.Infrastructure.Resources.gateways:type_name -> encore.runtime.v1.Gateway
+	6,  // 55: encore.runtime.v1.Infrastructure.Resources.sql_clusters:type_name -> encore.runtime.v1.SQLCluster
+	20, // 56: encore.runtime.v1.Infrastructure.Resources.pubsub_clusters:type_name -> encore.runtime.v1.PubSubCluster
+	14, // 57: encore.runtime.v1.Infrastructure.Resources.redis_clusters:type_name -> encore.runtime.v1.RedisCluster
+	19, // 58: encore.runtime.v1.Infrastructure.Resources.app_secrets:type_name -> encore.runtime.v1.AppSecret
+	23, // 59: encore.runtime.v1.Infrastructure.Resources.bucket_clusters:type_name -> encore.runtime.v1.BucketCluster
+	5,  // 60: encore.runtime.v1.Infrastructure.Resources.secret_providers:type_name -> encore.runtime.v1.SecretProvider
+	51, // 61: encore.runtime.v1.SQLRole.CredentialRotation.ttl:type_name -> google.protobuf.Duration
+	50, // 62: encore.runtime.v1.RedisRole.AuthACL.password:type_name -> encore.runtime.v1.SecretData
+	37, // 63: encore.runtime.v1.PubSubCluster.Kafka.sasl:type_name -> encore.runtime.v1.PubSubCluster.Kafka.SASL
+	50, // 64: encore.runtime.v1.PubSubCluster.Kafka.SASL.password:type_name -> encore.runtime.v1.SecretData
+	51, // 65: encore.runtime.v1.PubSubSubscription.GCPConfig.ack_deadline:type_name -> google.protobuf.Duration
+	51, // 66: encore.runtime.v1.PubSubSubscription.GCPConfig.message_retention:type_name -> google.protobuf.Duration
+	50, // 67: encore.runtime.v1.BucketCluster.S3.secret_access_key:type_name -> encore.runtime.v1.SecretData
+	45, // 68: encore.runtime.v1.BucketCluster.GCS.local_sign:type_name -> encore.runtime.v1.BucketCluster.GCS.LocalSignOptions
+	51, // 69: encore.runtime.v1.Gateway.RateLimit.window:type_name -> google.protobuf.Duration
+	51, // 70: encore.runtime.v1.Gateway.RouteTimeout.timeout:type_name -> google.protobuf.Duration
+	49, // 71: encore.runtime.v1.Gateway.CORS.allowed_origins:type_name -> encore.runtime.v1.Gateway.CORSAllowedOrigins
+	49, // 72: encore.runtime.v1.Gateway.CORS.allowed_origins_without_credentials:type_name -> encore.runtime.v1.Gateway.CORSAllowedOrigins
+	73, // [73:73] is the sub-list for method output_type
+	73, // [73:73] is the sub-list for method input_type
+	73, // [73:73] is the sub-list for extension type_name
+	73, // [73:73] is the sub-list for extension extendee
+	0,  // [0:73] is the sub-list for field type_name
 }
 
 func init() { file_encore_runtime_v1_infra_proto_init() }
@@ -3185,37 +4241,45 @@ func file_encore_runtime_v1_infra_proto_init() {
 	file_encore_runtime_v1_infra_proto_msgTypes[1].OneofWrappers = []any{
 		(*SecretProvider_GcpSm)(nil),
 	}
-	file_encore_runtime_v1_infra_proto_msgTypes[3].OneofWrappers = []any{}
+	file_encore_runtime_v1_infra_proto_msgTypes[2].OneofWrappers = []any{}
 	file_encore_runtime_v1_infra_proto_msgTypes[4].OneofWrappers = []any{}
-	file_encore_runtime_v1_infra_proto_msgTypes[6].OneofWrappers = []any{}
-	file_encore_runtime_v1_infra_proto_msgTypes[10].OneofWrappers = []any{}
-	file_encore_runtime_v1_infra_proto_msgTypes[12].OneofWrappers = []any{
+	file_encore_runtime_v1_infra_proto_msgTypes[5].OneofWrappers = []any{}
+	file_encore_runtime_v1_infra_proto_msgTypes[7].OneofWrappers = []any{}
+	file_encore_runtime_v1_infra_proto_msgTypes[9].OneofWrappers = []any{}
+	file_encore_runtime_v1_infra_proto_msgTypes[11].OneofWrappers = []any{}
+	file_encore_runtime_v1_infra_proto_msgTypes[12].OneofWrappers = []any{}
+	file_encore_runtime_v1_infra_proto_msgTypes[13].OneofWrappers = []any{
 		(*RedisRole_Acl)(nil),
 		(*RedisRole_AuthString)(nil),
 	}
-	file_encore_runtime_v1_infra_proto_msgTypes[13].OneofWrappers = []any{}
-	file_encore_runtime_v1_infra_proto_msgTypes[15].OneofWrappers = []any{
+	file_encore_runtime_v1_infra_proto_msgTypes[14].OneofWrappers = []any{}
+	file_encore_runtime_v1_infra_proto_msgTypes[16].OneofWrappers = []any{
 		(*PubSubCluster_Encore)(nil),
 		(*PubSubCluster_Aws)(nil),
 		(*PubSubCluster_Gcp)(nil),
 		(*PubSubCluster_Azure)(nil),
 		(*PubSubCluster_Nsq)(nil),
+		(*PubSubCluster_Kafka_)(nil),
 	}
-	file_encore_runtime_v1_infra_proto_msgTypes[16].OneofWrappers = []any{
+	file_encore_runtime_v1_infra_proto_msgTypes[17].OneofWrappers = []any{
 		(*PubSubTopic_GcpConfig)(nil),
+		(*PubSubTopic_KafkaConfig_)(nil),
 	}
-	file_encore_runtime_v1_infra_proto_msgTypes[17].OneofWrappers = []any{
+	file_encore_runtime_v1_infra_proto_msgTypes[18].OneofWrappers = []any{
 		(*PubSubSubscription_GcpConfig)(nil),
+		(*PubSubSubscription_KafkaConfig_)(nil),
 	}
-	file_encore_runtime_v1_infra_proto_msgTypes[18].OneofWrappers = []any{
+	file_encore_runtime_v1_infra_proto_msgTypes[19].OneofWrappers = []any{
 		(*BucketCluster_S3_)(nil),
 		(*BucketCluster_Gcs)(nil),
 	}
-	file_encore_runtime_v1_infra_proto_msgTypes[19].OneofWrappers = []any{}
-	file_encore_runtime_v1_infra_proto_msgTypes[31].OneofWrappers = []any{}
+	file_encore_runtime_v1_infra_proto_msgTypes[20].OneofWrappers = []any{}
+	file_encore_runtime_v1_infra_proto_msgTypes[21].OneofWrappers = []any{}
 	file_encore_runtime_v1_infra_proto_msgTypes[32].OneofWrappers = []any{}
-	file_encore_runtime_v1_infra_proto_msgTypes[33].OneofWrappers = []any{}
-	file_encore_runtime_v1_infra_proto_msgTypes[35].OneofWrappers = []any{
+	file_encore_runtime_v1_infra_proto_msgTypes[38].OneofWrappers = []any{}
+	file_encore_runtime_v1_infra_proto_msgTypes[39].OneofWrappers = []any{}
+	file_encore_runtime_v1_infra_proto_msgTypes[40].OneofWrappers = []any{}
+	file_encore_runtime_v1_infra_proto_msgTypes[44].OneofWrappers = []any{
 		(*Gateway_CORS_AllowedOrigins)(nil),
 		(*Gateway_CORS_UnsafeAllowAllOriginsWithCredentials)(nil),
 	}
@@ -3224,8 +4288,8 @@ func file_encore_runtime_v1_infra_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_encore_runtime_v1_infra_proto_rawDesc), len(file_encore_runtime_v1_infra_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   37,
+			NumEnums:      4,
+			NumMessages:   46,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -137,6 +137,160 @@ func (Environment_Cloud) EnumDescriptor() ([]byte, []int) {
 	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{1, 1}
 }
 
+type HostedService_ErrorFormat int32
+
+const (
+	HostedService_ERROR_FORMAT_UNSPECIFIED HostedService_ErrorFormat = 0
+	// Encore's standard error envelope, served as application/json.
+	HostedService_ERROR_FORMAT_ENCORE HostedService_ErrorFormat = 1
+	// RFC 7807 problem details, served as application/problem+json.
+	HostedService_ERROR_FORMAT_PROBLEM_JSON HostedService_ErrorFormat = 2
+)
+
+// Enum value maps for HostedService_ErrorFormat.
+var (
+	HostedService_ErrorFormat_name = map[int32]string{
+		0: "ERROR_FORMAT_UNSPECIFIED",
+		1: "ERROR_FORMAT_ENCORE",
+		2: "ERROR_FORMAT_PROBLEM_JSON",
+	}
+	HostedService_ErrorFormat_value = map[string]int32{
+		"ERROR_FORMAT_UNSPECIFIED":  0,
+		"ERROR_FORMAT_ENCORE":       1,
+		"ERROR_FORMAT_PROBLEM_JSON": 2,
+	}
+)
+
+func (x HostedService_ErrorFormat) Enum() *HostedService_ErrorFormat {
+	p := new(HostedService_ErrorFormat)
+	*p = x
+	return p
+}
+
+func (x HostedService_ErrorFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HostedService_ErrorFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_runtime_v1_runtime_proto_enumTypes[2].Descriptor()
+}
+
+func (HostedService_ErrorFormat) Type() protoreflect.EnumType {
+	return &file_encore_runtime_v1_runtime_proto_enumTypes[2]
+}
+
+func (x HostedService_ErrorFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HostedService_ErrorFormat.Descriptor instead.
+func (HostedService_ErrorFormat) EnumDescriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{5, 0}
+}
+
+type HostedService_LogFormat int32
+
+const (
+	HostedService_LOG_FORMAT_UNSPECIFIED HostedService_LogFormat = 0
+	// Structured JSON logs, one object per line.
+	HostedService_LOG_FORMAT_JSON HostedService_LogFormat = 1
+	// Human-readable console logs.
+	HostedService_LOG_FORMAT_CONSOLE HostedService_LogFormat = 2
+)
+
+// Enum value maps for HostedService_LogFormat.
+var (
+	HostedService_LogFormat_name = map[int32]string{
+		0: "LOG_FORMAT_UNSPECIFIED",
+		1: "LOG_FORMAT_JSON",
+		2: "LOG_FORMAT_CONSOLE",
+	}
+	HostedService_LogFormat_value = map[string]int32{
+		"LOG_FORMAT_UNSPECIFIED": 0,
+		"LOG_FORMAT_JSON":        1,
+		"LOG_FORMAT_CONSOLE":     2,
+	}
+)
+
+func (x HostedService_LogFormat) Enum() *HostedService_LogFormat {
+	p := new(HostedService_LogFormat)
+	*p = x
+	return p
+}
+
+func (x HostedService_LogFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HostedService_LogFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_runtime_v1_runtime_proto_enumTypes[3].Descriptor()
+}
+
+func (HostedService_LogFormat) Type() protoreflect.EnumType {
+	return &file_encore_runtime_v1_runtime_proto_enumTypes[3]
+}
+
+func (x HostedService_LogFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HostedService_LogFormat.Descriptor instead.
+func (HostedService_LogFormat) EnumDescriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{5, 1}
+}
+
+type ServiceDiscovery_LoadBalancing int32
+
+const (
+	ServiceDiscovery_LOAD_BALANCING_UNSPECIFIED       ServiceDiscovery_LoadBalancing = 0
+	ServiceDiscovery_LOAD_BALANCING_ROUND_ROBIN       ServiceDiscovery_LoadBalancing = 1
+	ServiceDiscovery_LOAD_BALANCING_WEIGHTED_RANDOM   ServiceDiscovery_LoadBalancing = 2
+	ServiceDiscovery_LOAD_BALANCING_LEAST_CONNECTIONS ServiceDiscovery_LoadBalancing = 3
+)
+
+// Enum value maps for ServiceDiscovery_LoadBalancing.
+var (
+	ServiceDiscovery_LoadBalancing_name = map[int32]string{
+		0: "LOAD_BALANCING_UNSPECIFIED",
+		1: "LOAD_BALANCING_ROUND_ROBIN",
+		2: "LOAD_BALANCING_WEIGHTED_RANDOM",
+		3: "LOAD_BALANCING_LEAST_CONNECTIONS",
+	}
+	ServiceDiscovery_LoadBalancing_value = map[string]int32{
+		"LOAD_BALANCING_UNSPECIFIED":       0,
+		"LOAD_BALANCING_ROUND_ROBIN":       1,
+		"LOAD_BALANCING_WEIGHTED_RANDOM":   2,
+		"LOAD_BALANCING_LEAST_CONNECTIONS": 3,
+	}
+)
+
+func (x ServiceDiscovery_LoadBalancing) Enum() *ServiceDiscovery_LoadBalancing {
+	p := new(ServiceDiscovery_LoadBalancing)
+	*p = x
+	return p
+}
+
+func (x ServiceDiscovery_LoadBalancing) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ServiceDiscovery_LoadBalancing) Descriptor() protoreflect.EnumDescriptor {
+	return file_encore_runtime_v1_runtime_proto_enumTypes[4].Descriptor()
+}
+
+func (ServiceDiscovery_LoadBalancing) Type() protoreflect.EnumType {
+	return &file_encore_runtime_v1_runtime_proto_enumTypes[4]
+}
+
+func (x ServiceDiscovery_LoadBalancing) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ServiceDiscovery_LoadBalancing.Descriptor instead.
+func (ServiceDiscovery_LoadBalancing) EnumDescriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{11, 0}
+}
+
 type RuntimeConfig struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Environment    *Environment           `protobuf:"bytes,1,opt,name=environment,proto3" json:"environment,omitempty"`
@@ -313,7 +467,18 @@ type Deployment struct {
 	// Graceful shutdown behavior.
 	GracefulShutdown *GracefulShutdown `protobuf:"bytes,9,opt,name=graceful_shutdown,json=gracefulShutdown,proto3" json:"graceful_shutdown,omitempty"`
 	// The metrics used by this deployment.
-	Metrics       []*Metric `protobuf:"bytes,10,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	Metrics []*Metric `protobuf:"bytes,10,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	// External (non-Encore) HTTP dependencies the runtime should
+	// provide shared, bounded HTTP clients for.
+	ExternalHttpDependencies []*ExternalHTTPDependency `protobuf:"bytes,11,rep,name=external_http_dependencies,json=externalHttpDependencies,proto3" json:"external_http_dependencies,omitempty"`
+	// The version control revision the deployment was built from, if known.
+	VcsRevision *string `protobuf:"bytes,12,opt,name=vcs_revision,json=vcsRevision,proto3,oneof" json:"vcs_revision,omitempty"`
+	// Whether the deployment was built with uncommitted changes.
+	VcsUncommitted bool `protobuf:"varint,13,opt,name=vcs_uncommitted,json=vcsUncommitted,proto3" json:"vcs_uncommitted,omitempty"`
+	// The percentage (0-100) of the traffic to the hosted services this
+	// deployment should receive, for splitting traffic between deployments
+	// of the same services. If unset the deployment receives all traffic.
+	TrafficWeight *uint32 `protobuf:"varint,14,opt,name=traffic_weight,json=trafficWeight,proto3,oneof" json:"traffic_weight,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -418,6 +583,139 @@ func (x *Deployment) GetMetrics() []*Metric {
 	return nil
 }
 
+func (x *Deployment) GetExternalHttpDependencies() []*ExternalHTTPDependency {
+	if x != nil {
+		return x.ExternalHttpDependencies
+	}
+	return nil
+}
+
+func (x *Deployment) GetVcsRevision() string {
+	if x != nil && x.VcsRevision != nil {
+		return *x.VcsRevision
+	}
+	return ""
+}
+
+func (x *Deployment) GetVcsUncommitted() bool {
+	if x != nil {
+		return x.VcsUncommitted
+	}
+	return false
+}
+
+func (x *Deployment) GetTrafficWeight() uint32 {
+	if x != nil && x.TrafficWeight != nil {
+		return *x.TrafficWeight
+	}
+	return 0
+}
+
+// Describes an external HTTP service and how to connect to it.
+type ExternalHTTPDependency struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the dependency, used to look up its client.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The base URL of the service (including scheme and optional port).
+	BaseUrl string `protobuf:"bytes,2,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
+	// The maximum number of connections to open to the service.
+	// If zero the number of connections is unbounded.
+	MaxConns int32 `protobuf:"varint,3,opt,name=max_conns,json=maxConns,proto3" json:"max_conns,omitempty"`
+	// The maximum number of idle connections to keep open.
+	// If zero the runtime default is used.
+	MaxIdleConns int32 `protobuf:"varint,4,opt,name=max_idle_conns,json=maxIdleConns,proto3" json:"max_idle_conns,omitempty"`
+	// How long an idle connection is kept open before being closed.
+	// If unset the runtime default is used.
+	IdleConnTimeout *durationpb.Duration `protobuf:"bytes,5,opt,name=idle_conn_timeout,json=idleConnTimeout,proto3,oneof" json:"idle_conn_timeout,omitempty"`
+	// How long to wait for a connection to be established.
+	// If unset the runtime default is used.
+	ConnectTimeout *durationpb.Duration `protobuf:"bytes,6,opt,name=connect_timeout,json=connectTimeout,proto3,oneof" json:"connect_timeout,omitempty"`
+	// The maximum duration of a request, including reading the response body.
+	// If unset requests have no timeout.
+	RequestTimeout *durationpb.Duration `protobuf:"bytes,7,opt,name=request_timeout,json=requestTimeout,proto3,oneof" json:"request_timeout,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ExternalHTTPDependency) Reset() {
+	*x = ExternalHTTPDependency{}
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExternalHTTPDependency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExternalHTTPDependency) ProtoMessage() {}
+
+func (x *ExternalHTTPDependency) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExternalHTTPDependency.ProtoReflect.Descriptor instead.
+func (*ExternalHTTPDependency) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ExternalHTTPDependency) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExternalHTTPDependency) GetBaseUrl() string {
+	if x != nil {
+		return x.BaseUrl
+	}
+	return ""
+}
+
+func (x *ExternalHTTPDependency) GetMaxConns() int32 {
+	if x != nil {
+		return x.MaxConns
+	}
+	return 0
+}
+
+func (x *ExternalHTTPDependency) GetMaxIdleConns() int32 {
+	if x != nil {
+		return x.MaxIdleConns
+	}
+	return 0
+}
+
+func (x *ExternalHTTPDependency) GetIdleConnTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.IdleConnTimeout
+	}
+	return nil
+}
+
+func (x *ExternalHTTPDependency) GetConnectTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.ConnectTimeout
+	}
+	return nil
+}
+
+func (x *ExternalHTTPDependency) GetRequestTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.RequestTimeout
+	}
+	return nil
+}
+
 type Observability struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The observability providers to use.
@@ -430,7 +728,7 @@ type Observability struct {
 
 func (x *Observability) Reset() {
 	*x = Observability{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[3]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -442,7 +740,7 @@ func (x *Observability) String() string {
 func (*Observability) ProtoMessage() {}
 
 func (x *Observability) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[3]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -455,7 +753,7 @@ func (x *Observability) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Observability.ProtoReflect.Descriptor instead.
 func (*Observability) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{3}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Observability) GetTracing() []*TracingProvider {
@@ -490,14 +788,26 @@ type HostedService struct {
 	WorkerThreads *int32 `protobuf:"varint,2,opt,name=worker_threads,json=workerThreads,proto3,oneof" json:"worker_threads,omitempty"`
 	// The log configuration to use for this service.
 	// If unset it defaults to "trace".
-	LogConfig     *string `protobuf:"bytes,3,opt,name=log_config,json=logConfig,proto3,oneof" json:"log_config,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	LogConfig *string `protobuf:"bytes,3,opt,name=log_config,json=logConfig,proto3,oneof" json:"log_config,omitempty"`
+	// The format to use when rendering error responses.
+	// If unset it defaults to ERROR_FORMAT_ENCORE.
+	ErrorFormat *HostedService_ErrorFormat `protobuf:"varint,4,opt,name=error_format,json=errorFormat,proto3,enum=encore.runtime.v1.HostedService_ErrorFormat,oneof" json:"error_format,omitempty"`
+	// The format to write logs in.
+	// If unset the runtime's default format is used.
+	LogFormat *HostedService_LogFormat `protobuf:"varint,5,opt,name=log_format,json=logFormat,proto3,enum=encore.runtime.v1.HostedService_LogFormat,oneof" json:"log_format,omitempty"`
+	// The health check the service responds to on its listen address,
+	// used to determine when the service is ready to receive requests.
+	HealthCheck *HostedService_HealthCheck `protobuf:"bytes,6,opt,name=health_check,json=healthCheck,proto3,oneof" json:"health_check,omitempty"`
+	// The default timeout for requests handled by the service.
+	// If unset requests don't time out.
+	RequestTimeout *durationpb.Duration `protobuf:"bytes,7,opt,name=request_timeout,json=requestTimeout,proto3,oneof" json:"request_timeout,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *HostedService) Reset() {
 	*x = HostedService{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[4]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -509,7 +819,7 @@ func (x *HostedService) String() string {
 func (*HostedService) ProtoMessage() {}
 
 func (x *HostedService) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[4]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -522,7 +832,7 @@ func (x *HostedService) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HostedService.ProtoReflect.Descriptor instead.
 func (*HostedService) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{4}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *HostedService) GetName() string {
@@ -546,6 +856,34 @@ func (x *HostedService) GetLogConfig() string {
 	return ""
 }
 
+func (x *HostedService) GetErrorFormat() HostedService_ErrorFormat {
+	if x != nil && x.ErrorFormat != nil {
+		return *x.ErrorFormat
+	}
+	return HostedService_ERROR_FORMAT_UNSPECIFIED
+}
+
+func (x *HostedService) GetLogFormat() HostedService_LogFormat {
+	if x != nil && x.LogFormat != nil {
+		return *x.LogFormat
+	}
+	return HostedService_LOG_FORMAT_UNSPECIFIED
+}
+
+func (x *HostedService) GetHealthCheck() *HostedService_HealthCheck {
+	if x != nil {
+		return x.HealthCheck
+	}
+	return nil
+}
+
+func (x *HostedService) GetRequestTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.RequestTimeout
+	}
+	return nil
+}
+
 type ServiceAuth struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The auth method to use.
@@ -561,7 +899,7 @@ type ServiceAuth struct {
 
 func (x *ServiceAuth) Reset() {
 	*x = ServiceAuth{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[5]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -573,7 +911,7 @@ func (x *ServiceAuth) String() string {
 func (*ServiceAuth) ProtoMessage() {}
 
 func (x *ServiceAuth) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[5]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -586,7 +924,7 @@ func (x *ServiceAuth) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceAuth.ProtoReflect.Descriptor instead.
 func (*ServiceAuth) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{5}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ServiceAuth) GetAuthMethod() isServiceAuth_AuthMethod {
@@ -645,7 +983,7 @@ type TracingProvider struct {
 
 func (x *TracingProvider) Reset() {
 	*x = TracingProvider{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[6]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -657,7 +995,7 @@ func (x *TracingProvider) String() string {
 func (*TracingProvider) ProtoMessage() {}
 
 func (x *TracingProvider) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[6]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -670,7 +1008,7 @@ func (x *TracingProvider) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TracingProvider.ProtoReflect.Descriptor instead.
 func (*TracingProvider) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{6}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *TracingProvider) GetRid() string {
@@ -725,7 +1063,7 @@ type MetricsProvider struct {
 
 func (x *MetricsProvider) Reset() {
 	*x = MetricsProvider{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[7]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -737,7 +1075,7 @@ func (x *MetricsProvider) String() string {
 func (*MetricsProvider) ProtoMessage() {}
 
 func (x *MetricsProvider) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[7]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -750,7 +1088,7 @@ func (x *MetricsProvider) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricsProvider.ProtoReflect.Descriptor instead.
 func (*MetricsProvider) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{7}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *MetricsProvider) GetRid() string {
@@ -863,7 +1201,7 @@ type LogsProvider struct {
 
 func (x *LogsProvider) Reset() {
 	*x = LogsProvider{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[8]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -875,7 +1213,7 @@ func (x *LogsProvider) String() string {
 func (*LogsProvider) ProtoMessage() {}
 
 func (x *LogsProvider) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[8]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -888,7 +1226,7 @@ func (x *LogsProvider) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogsProvider.ProtoReflect.Descriptor instead.
 func (*LogsProvider) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{8}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *LogsProvider) GetRid() string {
@@ -908,7 +1246,7 @@ type EncoreAuthKey struct {
 
 func (x *EncoreAuthKey) Reset() {
 	*x = EncoreAuthKey{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[9]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -920,7 +1258,7 @@ func (x *EncoreAuthKey) String() string {
 func (*EncoreAuthKey) ProtoMessage() {}
 
 func (x *EncoreAuthKey) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[9]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -933,7 +1271,7 @@ func (x *EncoreAuthKey) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EncoreAuthKey.ProtoReflect.Descriptor instead.
 func (*EncoreAuthKey) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{9}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *EncoreAuthKey) GetId() uint32 {
@@ -954,14 +1292,17 @@ func (x *EncoreAuthKey) GetData() *SecretData {
 type ServiceDiscovery struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Where services are located, keyed by the service name.
-	Services      map[string]*ServiceDiscovery_Location `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Services map[string]*ServiceDiscovery_Location `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// How connections to other services are managed.
+	// If unset the runtime defaults are used.
+	Connections   *ServiceDiscovery_Connections `protobuf:"bytes,2,opt,name=connections,proto3" json:"connections,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ServiceDiscovery) Reset() {
 	*x = ServiceDiscovery{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[10]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -973,7 +1314,7 @@ func (x *ServiceDiscovery) String() string {
 func (*ServiceDiscovery) ProtoMessage() {}
 
 func (x *ServiceDiscovery) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[10]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -986,7 +1327,7 @@ func (x *ServiceDiscovery) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceDiscovery.ProtoReflect.Descriptor instead.
 func (*ServiceDiscovery) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{10}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ServiceDiscovery) GetServices() map[string]*ServiceDiscovery_Location {
@@ -996,6 +1337,13 @@ func (x *ServiceDiscovery) GetServices() map[string]*ServiceDiscovery_Location {
 	return nil
 }
 
+func (x *ServiceDiscovery) GetConnections() *ServiceDiscovery_Connections {
+	if x != nil {
+		return x.Connections
+	}
+	return nil
+}
+
 // GracefulShutdown defines the graceful shutdown timings.
 type GracefulShutdown struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1013,14 +1361,21 @@ type GracefulShutdown struct {
 	// For example, if [total] is 10 seconds and [handlers] is 2 seconds,
 	// then we will cancel the context passed to handlers 8 seconds after
 	// a graceful shutdown is initiated.
-	Handlers      *durationpb.Duration `protobuf:"bytes,3,opt,name=handlers,proto3" json:"handlers,omitempty"`
+	Handlers *durationpb.Duration `protobuf:"bytes,3,opt,name=handlers,proto3" json:"handlers,omitempty"`
+	// PubSubDrain is how long before [total] runs out that PubSub subscriptions
+	// stop pulling new messages, letting in-flight messages finish processing
+	// until [handlers] cancels their context.
+	//
+	// If unset, subscriptions are not drained and keep pulling messages
+	// until the process exits.
+	PubsubDrain   *durationpb.Duration `protobuf:"bytes,4,opt,name=pubsub_drain,json=pubsubDrain,proto3,oneof" json:"pubsub_drain,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GracefulShutdown) Reset() {
 	*x = GracefulShutdown{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[11]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1032,7 +1387,7 @@ func (x *GracefulShutdown) String() string {
 func (*GracefulShutdown) ProtoMessage() {}
 
 func (x *GracefulShutdown) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[11]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1045,7 +1400,7 @@ func (x *GracefulShutdown) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GracefulShutdown.ProtoReflect.Descriptor instead.
 func (*GracefulShutdown) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{11}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GracefulShutdown) GetTotal() *durationpb.Duration {
@@ -1069,6 +1424,13 @@ func (x *GracefulShutdown) GetHandlers() *durationpb.Duration {
 	return nil
 }
 
+func (x *GracefulShutdown) GetPubsubDrain() *durationpb.Duration {
+	if x != nil {
+		return x.PubsubDrain
+	}
+	return nil
+}
+
 type EncorePlatform struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Auth keys for validating signed requests from the Encore Platform.
@@ -1081,7 +1443,7 @@ type EncorePlatform struct {
 
 func (x *EncorePlatform) Reset() {
 	*x = EncorePlatform{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[12]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1093,7 +1455,7 @@ func (x *EncorePlatform) String() string {
 func (*EncorePlatform) ProtoMessage() {}
 
 func (x *EncorePlatform) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[12]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1106,7 +1468,7 @@ func (x *EncorePlatform) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EncorePlatform.ProtoReflect.Descriptor instead.
 func (*EncorePlatform) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{12}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *EncorePlatform) GetPlatformSigningKeys() []*EncoreAuthKey {
@@ -1135,7 +1497,7 @@ type RateLimiter struct {
 
 func (x *RateLimiter) Reset() {
 	*x = RateLimiter{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[13]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1147,7 +1509,7 @@ func (x *RateLimiter) String() string {
 func (*RateLimiter) ProtoMessage() {}
 
 func (x *RateLimiter) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[13]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1160,7 +1522,7 @@ func (x *RateLimiter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RateLimiter.ProtoReflect.Descriptor instead.
 func (*RateLimiter) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{13}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *RateLimiter) GetKind() isRateLimiter_Kind {
@@ -1202,7 +1564,7 @@ type EncoreCloudProvider struct {
 
 func (x *EncoreCloudProvider) Reset() {
 	*x = EncoreCloudProvider{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[14]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1214,7 +1576,7 @@ func (x *EncoreCloudProvider) String() string {
 func (*EncoreCloudProvider) ProtoMessage() {}
 
 func (x *EncoreCloudProvider) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[14]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1227,7 +1589,7 @@ func (x *EncoreCloudProvider) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EncoreCloudProvider.ProtoReflect.Descriptor instead.
 func (*EncoreCloudProvider) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{14}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *EncoreCloudProvider) GetRid() string {
@@ -1263,7 +1625,7 @@ type Metric struct {
 
 func (x *Metric) Reset() {
 	*x = Metric{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[15]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1275,7 +1637,7 @@ func (x *Metric) String() string {
 func (*Metric) ProtoMessage() {}
 
 func (x *Metric) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[15]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1288,7 +1650,7 @@ func (x *Metric) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Metric.ProtoReflect.Descriptor instead.
 func (*Metric) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{15}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *Metric) GetEncoreName() string {
@@ -1305,6 +1667,51 @@ func (x *Metric) GetServices() []string {
 	return nil
 }
 
+type HostedService_HealthCheck struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The HTTP path to probe, such as "/__encore/healthz".
+	Path          string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HostedService_HealthCheck) Reset() {
+	*x = HostedService_HealthCheck{}
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HostedService_HealthCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostedService_HealthCheck) ProtoMessage() {}
+
+func (x *HostedService_HealthCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostedService_HealthCheck.ProtoReflect.Descriptor instead.
+func (*HostedService_HealthCheck) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{5, 0}
+}
+
+func (x *HostedService_HealthCheck) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
 type ServiceAuth_NoopAuth struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -1313,7 +1720,7 @@ type ServiceAuth_NoopAuth struct {
 
 func (x *ServiceAuth_NoopAuth) Reset() {
 	*x = ServiceAuth_NoopAuth{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[16]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1325,7 +1732,7 @@ func (x *ServiceAuth_NoopAuth) String() string {
 func (*ServiceAuth_NoopAuth) ProtoMessage() {}
 
 func (x *ServiceAuth_NoopAuth) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[16]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1338,7 +1745,7 @@ func (x *ServiceAuth_NoopAuth) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceAuth_NoopAuth.ProtoReflect.Descriptor instead.
 func (*ServiceAuth_NoopAuth) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{5, 0}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{6, 0}
 }
 
 type ServiceAuth_EncoreAuth struct {
@@ -1350,7 +1757,7 @@ type ServiceAuth_EncoreAuth struct {
 
 func (x *ServiceAuth_EncoreAuth) Reset() {
 	*x = ServiceAuth_EncoreAuth{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[17]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1362,7 +1769,7 @@ func (x *ServiceAuth_EncoreAuth) String() string {
 func (*ServiceAuth_EncoreAuth) ProtoMessage() {}
 
 func (x *ServiceAuth_EncoreAuth) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[17]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1375,7 +1782,7 @@ func (x *ServiceAuth_EncoreAuth) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceAuth_EncoreAuth.ProtoReflect.Descriptor instead.
 func (*ServiceAuth_EncoreAuth) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{5, 1}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{6, 1}
 }
 
 func (x *ServiceAuth_EncoreAuth) GetAuthKeys() []*EncoreAuthKey {
@@ -1405,7 +1812,7 @@ type TracingProvider_EncoreTracingProvider struct {
 
 func (x *TracingProvider_EncoreTracingProvider) Reset() {
 	*x = TracingProvider_EncoreTracingProvider{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[18]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1417,7 +1824,7 @@ func (x *TracingProvider_EncoreTracingProvider) String() string {
 func (*TracingProvider_EncoreTracingProvider) ProtoMessage() {}
 
 func (x *TracingProvider_EncoreTracingProvider) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[18]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1430,7 +1837,7 @@ func (x *TracingProvider_EncoreTracingProvider) ProtoReflect() protoreflect.Mess
 
 // Deprecated: Use TracingProvider_EncoreTracingProvider.ProtoReflect.Descriptor instead.
 func (*TracingProvider_EncoreTracingProvider) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{6, 0}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{7, 0}
 }
 
 func (x *TracingProvider_EncoreTracingProvider) GetTraceEndpoint() string {
@@ -1476,7 +1883,7 @@ type TracingProvider_SamplingConfig struct {
 
 func (x *TracingProvider_SamplingConfig) Reset() {
 	*x = TracingProvider_SamplingConfig{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[19]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1488,7 +1895,7 @@ func (x *TracingProvider_SamplingConfig) String() string {
 func (*TracingProvider_SamplingConfig) ProtoMessage() {}
 
 func (x *TracingProvider_SamplingConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[19]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1501,7 +1908,7 @@ func (x *TracingProvider_SamplingConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TracingProvider_SamplingConfig.ProtoReflect.Descriptor instead.
 func (*TracingProvider_SamplingConfig) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{6, 1}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{7, 1}
 }
 
 func (x *TracingProvider_SamplingConfig) GetRate() float64 {
@@ -1612,7 +2019,7 @@ type TracingProvider_SamplingConfig_Endpoint struct {
 
 func (x *TracingProvider_SamplingConfig_Endpoint) Reset() {
 	*x = TracingProvider_SamplingConfig_Endpoint{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[20]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1624,7 +2031,7 @@ func (x *TracingProvider_SamplingConfig_Endpoint) String() string {
 func (*TracingProvider_SamplingConfig_Endpoint) ProtoMessage() {}
 
 func (x *TracingProvider_SamplingConfig_Endpoint) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[20]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1637,7 +2044,7 @@ func (x *TracingProvider_SamplingConfig_Endpoint) ProtoReflect() protoreflect.Me
 
 // Deprecated: Use TracingProvider_SamplingConfig_Endpoint.ProtoReflect.Descriptor instead.
 func (*TracingProvider_SamplingConfig_Endpoint) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{6, 1, 0}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{7, 1, 0}
 }
 
 func (x *TracingProvider_SamplingConfig_Endpoint) GetService() string {
@@ -1664,7 +2071,7 @@ type TracingProvider_SamplingConfig_PubSubSubscription struct {
 
 func (x *TracingProvider_SamplingConfig_PubSubSubscription) Reset() {
 	*x = TracingProvider_SamplingConfig_PubSubSubscription{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[21]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1676,7 +2083,7 @@ func (x *TracingProvider_SamplingConfig_PubSubSubscription) String() string {
 func (*TracingProvider_SamplingConfig_PubSubSubscription) ProtoMessage() {}
 
 func (x *TracingProvider_SamplingConfig_PubSubSubscription) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[21]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1689,7 +2096,7 @@ func (x *TracingProvider_SamplingConfig_PubSubSubscription) ProtoReflect() proto
 
 // Deprecated: Use TracingProvider_SamplingConfig_PubSubSubscription.ProtoReflect.Descriptor instead.
 func (*TracingProvider_SamplingConfig_PubSubSubscription) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{6, 1, 1}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{7, 1, 1}
 }
 
 func (x *TracingProvider_SamplingConfig_PubSubSubscription) GetTopic() string {
@@ -1725,7 +2132,7 @@ type MetricsProvider_GCPCloudMonitoring struct {
 
 func (x *MetricsProvider_GCPCloudMonitoring) Reset() {
 	*x = MetricsProvider_GCPCloudMonitoring{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[22]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1737,7 +2144,7 @@ func (x *MetricsProvider_GCPCloudMonitoring) String() string {
 func (*MetricsProvider_GCPCloudMonitoring) ProtoMessage() {}
 
 func (x *MetricsProvider_GCPCloudMonitoring) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[22]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1750,7 +2157,7 @@ func (x *MetricsProvider_GCPCloudMonitoring) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use MetricsProvider_GCPCloudMonitoring.ProtoReflect.Descriptor instead.
 func (*MetricsProvider_GCPCloudMonitoring) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{7, 0}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{8, 0}
 }
 
 func (x *MetricsProvider_GCPCloudMonitoring) GetProjectId() string {
@@ -1791,7 +2198,7 @@ type MetricsProvider_AWSCloudWatch struct {
 
 func (x *MetricsProvider_AWSCloudWatch) Reset() {
 	*x = MetricsProvider_AWSCloudWatch{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[23]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1803,7 +2210,7 @@ func (x *MetricsProvider_AWSCloudWatch) String() string {
 func (*MetricsProvider_AWSCloudWatch) ProtoMessage() {}
 
 func (x *MetricsProvider_AWSCloudWatch) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[23]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1816,7 +2223,7 @@ func (x *MetricsProvider_AWSCloudWatch) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricsProvider_AWSCloudWatch.ProtoReflect.Descriptor instead.
 func (*MetricsProvider_AWSCloudWatch) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{7, 1}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{8, 1}
 }
 
 func (x *MetricsProvider_AWSCloudWatch) GetNamespace() string {
@@ -1836,7 +2243,7 @@ type MetricsProvider_PrometheusRemoteWrite struct {
 
 func (x *MetricsProvider_PrometheusRemoteWrite) Reset() {
 	*x = MetricsProvider_PrometheusRemoteWrite{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[24]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1848,7 +2255,7 @@ func (x *MetricsProvider_PrometheusRemoteWrite) String() string {
 func (*MetricsProvider_PrometheusRemoteWrite) ProtoMessage() {}
 
 func (x *MetricsProvider_PrometheusRemoteWrite) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[24]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1861,7 +2268,7 @@ func (x *MetricsProvider_PrometheusRemoteWrite) ProtoReflect() protoreflect.Mess
 
 // Deprecated: Use MetricsProvider_PrometheusRemoteWrite.ProtoReflect.Descriptor instead.
 func (*MetricsProvider_PrometheusRemoteWrite) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{7, 2}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{8, 2}
 }
 
 func (x *MetricsProvider_PrometheusRemoteWrite) GetRemoteWriteUrl() *SecretData {
@@ -1881,7 +2288,7 @@ type MetricsProvider_Datadog struct {
 
 func (x *MetricsProvider_Datadog) Reset() {
 	*x = MetricsProvider_Datadog{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[25]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1893,7 +2300,7 @@ func (x *MetricsProvider_Datadog) String() string {
 func (*MetricsProvider_Datadog) ProtoMessage() {}
 
 func (x *MetricsProvider_Datadog) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[25]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1906,7 +2313,7 @@ func (x *MetricsProvider_Datadog) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricsProvider_Datadog.ProtoReflect.Descriptor instead.
 func (*MetricsProvider_Datadog) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{7, 3}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{8, 3}
 }
 
 func (x *MetricsProvider_Datadog) GetSite() string {
@@ -1923,19 +2330,79 @@ func (x *MetricsProvider_Datadog) GetApiKey() *SecretData {
 	return nil
 }
 
+type ServiceDiscovery_Connections struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The maximum number of idle connections to keep open per service.
+	MaxIdleConns int32 `protobuf:"varint,1,opt,name=max_idle_conns,json=maxIdleConns,proto3" json:"max_idle_conns,omitempty"`
+	// How long idle connections are kept open before being closed.
+	IdleConnTimeout *durationpb.Duration `protobuf:"bytes,2,opt,name=idle_conn_timeout,json=idleConnTimeout,proto3" json:"idle_conn_timeout,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ServiceDiscovery_Connections) Reset() {
+	*x = ServiceDiscovery_Connections{}
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceDiscovery_Connections) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceDiscovery_Connections) ProtoMessage() {}
+
+func (x *ServiceDiscovery_Connections) ProtoReflect() protoreflect.Message {
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceDiscovery_Connections.ProtoReflect.Descriptor instead.
+func (*ServiceDiscovery_Connections) Descriptor() ([]byte, []int) {
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{11, 1}
+}
+
+func (x *ServiceDiscovery_Connections) GetMaxIdleConns() int32 {
+	if x != nil {
+		return x.MaxIdleConns
+	}
+	return 0
+}
+
+func (x *ServiceDiscovery_Connections) GetIdleConnTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.IdleConnTimeout
+	}
+	return nil
+}
+
 type ServiceDiscovery_Location struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The base URL of the service (including scheme and port).
 	BaseUrl string `protobuf:"bytes,1,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
 	// The auth methods to use when talking to this service.
-	AuthMethods   []*ServiceAuth `protobuf:"bytes,2,rep,name=auth_methods,json=authMethods,proto3" json:"auth_methods,omitempty"`
+	AuthMethods []*ServiceAuth `protobuf:"bytes,2,rep,name=auth_methods,json=authMethods,proto3" json:"auth_methods,omitempty"`
+	// How to balance requests across the service's endpoints.
+	// If unset the runtime default is used.
+	LoadBalancing *ServiceDiscovery_LoadBalancing `protobuf:"varint,3,opt,name=load_balancing,json=loadBalancing,proto3,enum=encore.runtime.v1.ServiceDiscovery_LoadBalancing,oneof" json:"load_balancing,omitempty"`
+	// The base URLs of additional endpoints of the service,
+	// such as replicas. Requests are balanced across them and base_url.
+	ReplicaUrls   []string `protobuf:"bytes,4,rep,name=replica_urls,json=replicaUrls,proto3" json:"replica_urls,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ServiceDiscovery_Location) Reset() {
 	*x = ServiceDiscovery_Location{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[29]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1947,7 +2414,7 @@ func (x *ServiceDiscovery_Location) String() string {
 func (*ServiceDiscovery_Location) ProtoMessage() {}
 
 func (x *ServiceDiscovery_Location) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[29]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1960,7 +2427,7 @@ func (x *ServiceDiscovery_Location) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceDiscovery_Location.ProtoReflect.Descriptor instead.
 func (*ServiceDiscovery_Location) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{10, 1}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{11, 2}
 }
 
 func (x *ServiceDiscovery_Location) GetBaseUrl() string {
@@ -1977,6 +2444,20 @@ func (x *ServiceDiscovery_Location) GetAuthMethods() []*ServiceAuth {
 	return nil
 }
 
+func (x *ServiceDiscovery_Location) GetLoadBalancing() ServiceDiscovery_LoadBalancing {
+	if x != nil && x.LoadBalancing != nil {
+		return *x.LoadBalancing
+	}
+	return ServiceDiscovery_LOAD_BALANCING_UNSPECIFIED
+}
+
+func (x *ServiceDiscovery_Location) GetReplicaUrls() []string {
+	if x != nil {
+		return x.ReplicaUrls
+	}
+	return nil
+}
+
 type RateLimiter_TokenBucket struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The rate (in events per per second) to allow.
@@ -1989,7 +2470,7 @@ type RateLimiter_TokenBucket struct {
 
 func (x *RateLimiter_TokenBucket) Reset() {
 	*x = RateLimiter_TokenBucket{}
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[30]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2001,7 +2482,7 @@ func (x *RateLimiter_TokenBucket) String() string {
 func (*RateLimiter_TokenBucket) ProtoMessage() {}
 
 func (x *RateLimiter_TokenBucket) ProtoReflect() protoreflect.Message {
-	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[30]
+	mi := &file_encore_runtime_v1_runtime_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2014,7 +2495,7 @@ func (x *RateLimiter_TokenBucket) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RateLimiter_TokenBucket.ProtoReflect.Descriptor instead.
 func (*RateLimiter_TokenBucket) Descriptor() ([]byte, []int) {
-	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{13, 0}
+	return file_encore_runtime_v1_runtime_proto_rawDescGZIP(), []int{14, 0}
 }
 
 func (x *RateLimiter_TokenBucket) GetRate() float64 {
@@ -2063,7 +2544,7 @@ const file_encore_runtime_v1_runtime_proto_rawDesc = "" +
 	"\fCLOUD_ENCORE\x10\x02\x12\r\n" +
 	"\tCLOUD_AWS\x10\x03\x12\r\n" +
 	"\tCLOUD_GCP\x10\x04\x12\x0f\n" +
-	"\vCLOUD_AZURE\x10\x05\"\xef\x04\n" +
+	"\vCLOUD_AZURE\x10\x05\"\xf9\x06\n" +
 	"\n" +
 	"Deployment\x12\x1b\n" +
 	"\tdeploy_id\x18\x01 \x01(\tR\bdeployId\x12;\n" +
@@ -2077,18 +2558,54 @@ const file_encore_runtime_v1_runtime_proto_rawDesc = "" +
 	"\x11service_discovery\x18\b \x01(\v2#.encore.runtime.v1.ServiceDiscoveryR\x10serviceDiscovery\x12P\n" +
 	"\x11graceful_shutdown\x18\t \x01(\v2#.encore.runtime.v1.GracefulShutdownR\x10gracefulShutdown\x123\n" +
 	"\ametrics\x18\n" +
-	" \x03(\v2\x19.encore.runtime.v1.MetricR\ametrics\"\xc0\x01\n" +
+	" \x03(\v2\x19.encore.runtime.v1.MetricR\ametrics\x12g\n" +
+	"\x1aexternal_http_dependencies\x18\v \x03(\v2).encore.runtime.v1.ExternalHTTPDependencyR\x18externalHttpDependencies\x12&\n" +
+	"\fvcs_revision\x18\f \x01(\tH\x00R\vvcsRevision\x88\x01\x01\x12'\n" +
+	"\x0fvcs_uncommitted\x18\r \x01(\bR\x0evcsUncommitted\x12*\n" +
+	"\x0etraffic_weight\x18\x0e \x01(\rH\x01R\rtrafficWeight\x88\x01\x01B\x0f\n" +
+	"\r_vcs_revisionB\x11\n" +
+	"\x0f_traffic_weight\"\xa6\x03\n" +
+	"\x16ExternalHTTPDependency\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
+	"\bbase_url\x18\x02 \x01(\tR\abaseUrl\x12\x1b\n" +
+	"\tmax_conns\x18\x03 \x01(\x05R\bmaxConns\x12$\n" +
+	"\x0emax_idle_conns\x18\x04 \x01(\x05R\fmaxIdleConns\x12J\n" +
+	"\x11idle_conn_timeout\x18\x05 \x01(\v2\x19.google.protobuf.DurationH\x00R\x0fidleConnTimeout\x88\x01\x01\x12G\n" +
+	"\x0fconnect_timeout\x18\x06 \x01(\v2\x19.google.protobuf.DurationH\x01R\x0econnectTimeout\x88\x01\x01\x12G\n" +
+	"\x0frequest_timeout\x18\a \x01(\v2\x19.google.protobuf.DurationH\x02R\x0erequestTimeout\x88\x01\x01B\x14\n" +
+	"\x12_idle_conn_timeoutB\x12\n" +
+	"\x10_connect_timeoutB\x12\n" +
+	"\x10_request_timeout\"\xc0\x01\n" +
 	"\rObservability\x12<\n" +
 	"\atracing\x18\x01 \x03(\v2\".encore.runtime.v1.TracingProviderR\atracing\x12<\n" +
 	"\ametrics\x18\x02 \x03(\v2\".encore.runtime.v1.MetricsProviderR\ametrics\x123\n" +
-	"\x04logs\x18\x03 \x03(\v2\x1f.encore.runtime.v1.LogsProviderR\x04logs\"\x95\x01\n" +
+	"\x04logs\x18\x03 \x03(\v2\x1f.encore.runtime.v1.LogsProviderR\x04logs\"\xfd\x05\n" +
 	"\rHostedService\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12*\n" +
 	"\x0eworker_threads\x18\x02 \x01(\x05H\x00R\rworkerThreads\x88\x01\x01\x12\"\n" +
 	"\n" +
-	"log_config\x18\x03 \x01(\tH\x01R\tlogConfig\x88\x01\x01B\x11\n" +
+	"log_config\x18\x03 \x01(\tH\x01R\tlogConfig\x88\x01\x01\x12T\n" +
+	"\ferror_format\x18\x04 \x01(\x0e2,.encore.runtime.v1.HostedService.ErrorFormatH\x02R\verrorFormat\x88\x01\x01\x12N\n" +
+	"\n" +
+	"log_format\x18\x05 \x01(\x0e2*.encore.runtime.v1.HostedService.LogFormatH\x03R\tlogFormat\x88\x01\x01\x12T\n" +
+	"\fhealth_check\x18\x06 \x01(\v2,.encore.runtime.v1.HostedService.HealthCheckH\x04R\vhealthCheck\x88\x01\x01\x12G\n" +
+	"\x0frequest_timeout\x18\a \x01(\v2\x19.google.protobuf.DurationH\x05R\x0erequestTimeout\x88\x01\x01\x1a!\n" +
+	"\vHealthCheck\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\"c\n" +
+	"\vErrorFormat\x12\x1c\n" +
+	"\x18ERROR_FORMAT_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13ERROR_FORMAT_ENCORE\x10\x01\x12\x1d\n" +
+	"\x19ERROR_FORMAT_PROBLEM_JSON\x10\x02\"T\n" +
+	"\tLogFormat\x12\x1a\n" +
+	"\x16LOG_FORMAT_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fLOG_FORMAT_JSON\x10\x01\x12\x16\n" +
+	"\x12LOG_FORMAT_CONSOLE\x10\x02B\x11\n" +
 	"\x0f_worker_threadsB\r\n" +
-	"\v_log_config\"\x82\x02\n" +
+	"\v_log_configB\x0f\n" +
+	"\r_error_formatB\r\n" +
+	"\v_log_formatB\x0f\n" +
+	"\r_health_checkB\x12\n" +
+	"\x10_request_timeout\"\x82\x02\n" +
 	"\vServiceAuth\x12=\n" +
 	"\x04noop\x18\n" +
 	" \x01(\v2'.encore.runtime.v1.ServiceAuth.NoopAuthH\x00R\x04noop\x12L\n" +
@@ -2159,19 +2676,33 @@ const file_encore_runtime_v1_runtime_proto_rawDesc = "" +
 	"\x03rid\x18\x01 \x01(\tR\x03rid\"R\n" +
 	"\rEncoreAuthKey\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x121\n" +
-	"\x04data\x18\x02 \x01(\v2\x1d.encore.runtime.v1.SecretDataR\x04data\"\xb6\x02\n" +
+	"\x04data\x18\x02 \x01(\v2\x1d.encore.runtime.v1.SecretDataR\x04data\"\xb7\x06\n" +
 	"\x10ServiceDiscovery\x12M\n" +
-	"\bservices\x18\x01 \x03(\v21.encore.runtime.v1.ServiceDiscovery.ServicesEntryR\bservices\x1ai\n" +
+	"\bservices\x18\x01 \x03(\v21.encore.runtime.v1.ServiceDiscovery.ServicesEntryR\bservices\x12Q\n" +
+	"\vconnections\x18\x02 \x01(\v2/.encore.runtime.v1.ServiceDiscovery.ConnectionsR\vconnections\x1ai\n" +
 	"\rServicesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12B\n" +
-	"\x05value\x18\x02 \x01(\v2,.encore.runtime.v1.ServiceDiscovery.LocationR\x05value:\x028\x01\x1ah\n" +
+	"\x05value\x18\x02 \x01(\v2,.encore.runtime.v1.ServiceDiscovery.LocationR\x05value:\x028\x01\x1az\n" +
+	"\vConnections\x12$\n" +
+	"\x0emax_idle_conns\x18\x01 \x01(\x05R\fmaxIdleConns\x12E\n" +
+	"\x11idle_conn_timeout\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x0fidleConnTimeout\x1a\xfd\x01\n" +
 	"\bLocation\x12\x19\n" +
 	"\bbase_url\x18\x01 \x01(\tR\abaseUrl\x12A\n" +
-	"\fauth_methods\x18\x02 \x03(\v2\x1e.encore.runtime.v1.ServiceAuthR\vauthMethods\"\xbc\x01\n" +
+	"\fauth_methods\x18\x02 \x03(\v2\x1e.encore.runtime.v1.ServiceAuthR\vauthMethods\x12]\n" +
+	"\x0eload_balancing\x18\x03 \x01(\x0e21.encore.runtime.v1.ServiceDiscovery.LoadBalancingH\x00R\rloadBalancing\x88\x01\x01\x12!\n" +
+	"\freplica_urls\x18\x04 \x03(\tR\vreplicaUrlsB\x11\n" +
+	"\x0f_load_balancing\"\x99\x01\n" +
+	"\rLoadBalancing\x12\x1e\n" +
+	"\x1aLOAD_BALANCING_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aLOAD_BALANCING_ROUND_ROBIN\x10\x01\x12\"\n" +
+	"\x1eLOAD_BALANCING_WEIGHTED_RANDOM\x10\x02\x12$\n" +
+	" LOAD_BALANCING_LEAST_CONNECTIONS\x10\x03\"\x90\x02\n" +
 	"\x10GracefulShutdown\x12/\n" +
 	"\x05total\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x05total\x12@\n" +
 	"\x0eshutdown_hooks\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\rshutdownHooks\x125\n" +
-	"\bhandlers\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\bhandlers\"\xc7\x01\n" +
+	"\bhandlers\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\bhandlers\x12A\n" +
+	"\fpubsub_drain\x18\x04 \x01(\v2\x19.google.protobuf.DurationH\x00R\vpubsubDrain\x88\x01\x01B\x0f\n" +
+	"\r_pubsub_drain\"\xc7\x01\n" +
 	"\x0eEncorePlatform\x12T\n" +
 	"\x15platform_signing_keys\x18\x01 \x03(\v2 .encore.runtime.v1.EncoreAuthKeyR\x13platformSigningKeys\x12N\n" +
 	"\fencore_cloud\x18\x02 \x01(\v2&.encore.runtime.v1.EncoreCloudProviderH\x00R\vencoreCloud\x88\x01\x01B\x0f\n" +
@@ -2204,99 +2735,117 @@ func file_encore_runtime_v1_runtime_proto_rawDescGZIP() []byte {
 	return file_encore_runtime_v1_runtime_proto_rawDescData
 }
 
-var file_encore_runtime_v1_runtime_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_encore_runtime_v1_runtime_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_encore_runtime_v1_runtime_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_encore_runtime_v1_runtime_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_encore_runtime_v1_runtime_proto_goTypes = []any{
 	(Environment_Type)(0),                                     // 0: encore.runtime.v1.Environment.Type
 	(Environment_Cloud)(0),                                    // 1: encore.runtime.v1.Environment.Cloud
-	(*RuntimeConfig)(nil),                                     // 2: encore.runtime.v1.RuntimeConfig
-	(*Environment)(nil),                                       // 3: encore.runtime.v1.Environment
-	(*Deployment)(nil),                                        // 4: encore.runtime.v1.Deployment
-	(*Observability)(nil),                                     // 5: encore.runtime.v1.Observability
-	(*HostedService)(nil),                                     // 6: encore.runtime.v1.HostedService
-	(*ServiceAuth)(nil),                                       // 7: encore.runtime.v1.ServiceAuth
-	(*TracingProvider)(nil),                                   // 8: encore.runtime.v1.TracingProvider
-	(*MetricsProvider)(nil),                                   // 9: encore.runtime.v1.MetricsProvider
-	(*LogsProvider)(nil),                                      // 10: encore.runtime.v1.LogsProvider
-	(*EncoreAuthKey)(nil),                                     // 11: encore.runtime.v1.EncoreAuthKey
-	(*ServiceDiscovery)(nil),                                  // 12: encore.runtime.v1.ServiceDiscovery
-	(*GracefulShutdown)(nil),                                  // 13: encore.runtime.v1.GracefulShutdown
-	(*EncorePlatform)(nil),                                    // 14: encore.runtime.v1.EncorePlatform
-	(*RateLimiter)(nil),                                       // 15: encore.runtime.v1.RateLimiter
-	(*EncoreCloudProvider)(nil),                               // 16: encore.runtime.v1.EncoreCloudProvider
-	(*Metric)(nil),                                            // 17: encore.runtime.v1.Metric
-	(*ServiceAuth_NoopAuth)(nil),                              // 18: encore.runtime.v1.ServiceAuth.NoopAuth
-	(*ServiceAuth_EncoreAuth)(nil),                            // 19: encore.runtime.v1.ServiceAuth.EncoreAuth
-	(*TracingProvider_EncoreTracingProvider)(nil),             // 20: encore.runtime.v1.TracingProvider.EncoreTracingProvider
-	(*TracingProvider_SamplingConfig)(nil),                    // 21: encore.runtime.v1.TracingProvider.SamplingConfig
-	(*TracingProvider_SamplingConfig_Endpoint)(nil),           // 22: encore.runtime.v1.TracingProvider.SamplingConfig.Endpoint
-	(*TracingProvider_SamplingConfig_PubSubSubscription)(nil), // 23: encore.runtime.v1.TracingProvider.SamplingConfig.PubSubSubscription
-	(*MetricsProvider_GCPCloudMonitoring)(nil),                // 24: encore.runtime.v1.MetricsProvider.GCPCloudMonitoring
-	(*MetricsProvider_AWSCloudWatch)(nil),                     // 25: encore.runtime.v1.MetricsProvider.AWSCloudWatch
-	(*MetricsProvider_PrometheusRemoteWrite)(nil),             // 26: encore.runtime.v1.MetricsProvider.PrometheusRemoteWrite
-	(*MetricsProvider_Datadog)(nil),                           // 27: encore.runtime.v1.MetricsProvider.Datadog
-	nil,                                                       // 28: encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.MonitoredResourceLabelsEntry
-	nil,                                                       // 29: encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.MetricNamesEntry
-	nil,                                                       // 30: encore.runtime.v1.ServiceDiscovery.ServicesEntry
-	(*ServiceDiscovery_Location)(nil),                         // 31: encore.runtime.v1.ServiceDiscovery.Location
-	(*RateLimiter_TokenBucket)(nil),                           // 32: encore.runtime.v1.RateLimiter.TokenBucket
-	(*Infrastructure)(nil),                                    // 33: encore.runtime.v1.Infrastructure
-	(*timestamppb.Timestamp)(nil),                             // 34: google.protobuf.Timestamp
-	(*durationpb.Duration)(nil),                               // 35: google.protobuf.Duration
-	(*SecretData)(nil),                                        // 36: encore.runtime.v1.SecretData
-	(*emptypb.Empty)(nil),                                     // 37: google.protobuf.Empty
+	(HostedService_ErrorFormat)(0),                            // 2: encore.runtime.v1.HostedService.ErrorFormat
+	(HostedService_LogFormat)(0),                              // 3: encore.runtime.v1.HostedService.LogFormat
+	(ServiceDiscovery_LoadBalancing)(0),                       // 4: encore.runtime.v1.ServiceDiscovery.LoadBalancing
+	(*RuntimeConfig)(nil),                                     // 5: encore.runtime.v1.RuntimeConfig
+	(*Environment)(nil),                                       // 6: encore.runtime.v1.Environment
+	(*Deployment)(nil),                                        // 7: encore.runtime.v1.Deployment
+	(*ExternalHTTPDependency)(nil),                            // 8: encore.runtime.v1.ExternalHTTPDependency
+	(*Observability)(nil),                                     // 9: encore.runtime.v1.Observability
+	(*HostedService)(nil),                                     // 10: encore.runtime.v1.HostedService
+	(*ServiceAuth)(nil),                                       // 11: encore.runtime.v1.ServiceAuth
+	(*TracingProvider)(nil),                                   // 12: encore.runtime.v1.TracingProvider
+	(*MetricsProvider)(nil),                                   // 13: encore.runtime.v1.MetricsProvider
+	(*LogsProvider)(nil),                                      // 14: encore.runtime.v1.LogsProvider
+	(*EncoreAuthKey)(nil),                                     // 15: encore.runtime.v1.EncoreAuthKey
+	(*ServiceDiscovery)(nil),                                  // 16: encore.runtime.v1.ServiceDiscovery
+	(*GracefulShutdown)(nil),                                  // 17: encore.runtime.v1.GracefulShutdown
+	(*EncorePlatform)(nil),                                    // 18: encore.runtime.v1.EncorePlatform
+	(*RateLimiter)(nil),                                       // 19: encore.runtime.v1.RateLimiter
+	(*EncoreCloudProvider)(nil),                               // 20: encore.runtime.v1.EncoreCloudProvider
+	(*Metric)(nil),                                            // 21: encore.runtime.v1.Metric
+	(*HostedService_HealthCheck)(nil),                         // 22: encore.runtime.v1.HostedService.HealthCheck
+	(*ServiceAuth_NoopAuth)(nil),                              // 23: encore.runtime.v1.ServiceAuth.NoopAuth
+	(*ServiceAuth_EncoreAuth)(nil),                            // 24: encore.runtime.v1.ServiceAuth.EncoreAuth
+	(*TracingProvider_EncoreTracingProvider)(nil),             // 25: encore.runtime.v1.TracingProvider.EncoreTracingProvider
+	(*TracingProvider_SamplingConfig)(nil),                    // 26: encore.runtime.v1.TracingProvider.SamplingConfig
+	(*TracingProvider_SamplingConfig_Endpoint)(nil),           // 27: encore.runtime.v1.TracingProvider.SamplingConfig.Endpoint
+	(*TracingProvider_SamplingConfig_PubSubSubscription)(nil), // 28: encore.runtime.v1.TracingProvider.SamplingConfig.PubSubSubscription
+	(*MetricsProvider_GCPCloudMonitoring)(nil),                // 29: encore.runtime.v1.MetricsProvider.GCPCloudMonitoring
+	(*MetricsProvider_AWSCloudWatch)(nil),                     // 30: encore.runtime.v1.MetricsProvider.AWSCloudWatch
+	(*MetricsProvider_PrometheusRemoteWrite)(nil),             // 31: encore.runtime.v1.MetricsProvider.PrometheusRemoteWrite
+	(*MetricsProvider_Datadog)(nil),                           // 32: encore.runtime.v1.MetricsProvider.Datadog
+	nil,                                                       // 33: encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.MonitoredResourceLabelsEntry
+	nil,                                                       // 34: encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.MetricNamesEntry
+	nil,                                                       // 35: encore.runtime.v1.ServiceDiscovery.ServicesEntry
+	(*ServiceDiscovery_Connections)(nil),                      // 36: encore.runtime.v1.ServiceDiscovery.Connections
+	(*ServiceDiscovery_Location)(nil),                         // 37: encore.runtime.v1.ServiceDiscovery.Location
+	(*RateLimiter_TokenBucket)(nil),                           // 38: encore.runtime.v1.RateLimiter.TokenBucket
+	(*Infrastructure)(nil),                                    // 39: encore.runtime.v1.Infrastructure
+	(*timestamppb.Timestamp)(nil),                             // 40: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),                               // 41: google.protobuf.Duration
+	(*SecretData)(nil),                                        // 42: encore.runtime.v1.SecretData
+	(*emptypb.Empty)(nil),                                     // 43: google.protobuf.Empty
 }
 var file_encore_runtime_v1_runtime_proto_depIdxs = []int32{
-	3,  // 0: encore.runtime.v1.RuntimeConfig.environment:type_name -> encore.runtime.v1.Environment
-	33, // 1: encore.runtime.v1.RuntimeConfig.infra:type_name -> encore.runtime.v1.Infrastructure
-	4,  // 2: encore.runtime.v1.RuntimeConfig.deployment:type_name -> encore.runtime.v1.Deployment
-	14, // 3: encore.runtime.v1.RuntimeConfig.encore_platform:type_name -> encore.runtime.v1.EncorePlatform
+	6,  // 0: encore.runtime.v1.RuntimeConfig.environment:type_name -> encore.runtime.v1.Environment
+	39, // 1: encore.runtime.v1.RuntimeConfig.infra:type_name -> encore.runtime.v1.Infrastructure
+	7,  // 2: encore.runtime.v1.RuntimeConfig.deployment:type_name -> encore.runtime.v1.Deployment
+	18, // 3: encore.runtime.v1.RuntimeConfig.encore_platform:type_name -> encore.runtime.v1.EncorePlatform
 	0,  // 4: encore.runtime.v1.Environment.env_type:type_name -> encore.runtime.v1.Environment.Type
 	1,  // 5: encore.runtime.v1.Environment.cloud:type_name -> encore.runtime.v1.Environment.Cloud
-	34, // 6: encore.runtime.v1.Deployment.deployed_at:type_name -> google.protobuf.Timestamp
-	6,  // 7: encore.runtime.v1.Deployment.hosted_services:type_name -> encore.runtime.v1.HostedService
-	7,  // 8: encore.runtime.v1.Deployment.auth_methods:type_name -> encore.runtime.v1.ServiceAuth
-	5,  // 9: encore.runtime.v1.Deployment.observability:type_name -> encore.runtime.v1.Observability
-	12, // 10: encore.runtime.v1.Deployment.service_discovery:type_name -> encore.runtime.v1.ServiceDiscovery
-	13, // 11: encore.runtime.v1.Deployment.graceful_shutdown:type_name -> encore.runtime.v1.GracefulShutdown
-	17, // 12: encore.runtime.v1.Deployment.metrics:type_name -> encore.runtime.v1.Metric
-	8,  // 13: encore.runtime.v1.Observability.tracing:type_name -> encore.runtime.v1.TracingProvider
-	9,  // 14: encore.runtime.v1.Observability.metrics:type_name -> encore.runtime.v1.MetricsProvider
-	10, // 15: encore.runtime.v1.Observability.logs:type_name -> encore.runtime.v1.LogsProvider
-	18, // 16: encore.runtime.v1.ServiceAuth.noop:type_name -> encore.runtime.v1.ServiceAuth.NoopAuth
-	19, // 17: encore.runtime.v1.ServiceAuth.encore_auth:type_name -> encore.runtime.v1.ServiceAuth.EncoreAuth
-	20, // 18: encore.runtime.v1.TracingProvider.encore:type_name -> encore.runtime.v1.TracingProvider.EncoreTracingProvider
-	35, // 19: encore.runtime.v1.MetricsProvider.collection_interval:type_name -> google.protobuf.Duration
-	24, // 20: encore.runtime.v1.MetricsProvider.encore_cloud:type_name -> encore.runtime.v1.MetricsProvider.GCPCloudMonitoring
-	24, // 21: encore.runtime.v1.MetricsProvider.gcp:type_name -> encore.runtime.v1.MetricsProvider.GCPCloudMonitoring
-	25, // 22: encore.runtime.v1.MetricsProvider.aws:type_name -> encore.runtime.v1.MetricsProvider.AWSCloudWatch
-	26, // 23: encore.runtime.v1.MetricsProvider.prom_remote_write:type_name -> encore.runtime.v1.MetricsProvider.PrometheusRemoteWrite
-	27, // 24: encore.runtime.v1.MetricsProvider.datadog:type_name -> encore.runtime.v1.MetricsProvider.Datadog
-	36, // 25: encore.runtime.v1.EncoreAuthKey.data:type_name -> encore.runtime.v1.SecretData
-	30, // 26: encore.runtime.v1.ServiceDiscovery.services:type_name -> encore.runtime.v1.ServiceDiscovery.ServicesEntry
-	35, // 27: encore.runtime.v1.GracefulShutdown.total:type_name -> google.protobuf.Duration
-	35, // 28: encore.runtime.v1.GracefulShutdown.shutdown_hooks:type_name -> google.protobuf.Duration
-	35, // 29: encore.runtime.v1.GracefulShutdown.handlers:type_name -> google.protobuf.Duration
-	11, // 30: encore.runtime.v1.EncorePlatform.platform_signing_keys:type_name -> encore.runtime.v1.EncoreAuthKey
-	16, // 31: encore.runtime.v1.EncorePlatform.encore_cloud:type_name -> encore.runtime.v1.EncoreCloudProvider
-	32, // 32: encore.runtime.v1.RateLimiter.token_bucket:type_name -> encore.runtime.v1.RateLimiter.TokenBucket
-	11, // 33: encore.runtime.v1.EncoreCloudProvider.auth_keys:type_name -> encore.runtime.v1.EncoreAuthKey
-	11, // 34: encore.runtime.v1.ServiceAuth.EncoreAuth.auth_keys:type_name -> encore.runtime.v1.EncoreAuthKey
-	21, // 35: encore.runtime.v1.TracingProvider.EncoreTracingProvider.sampling_config:type_name -> encore.runtime.v1.TracingProvider.SamplingConfig
-	37, // 36: encore.runtime.v1.TracingProvider.SamplingConfig.default:type_name -> google.protobuf.Empty
-	22, // 37: encore.runtime.v1.TracingProvider.SamplingConfig.endpoint:type_name -> encore.runtime.v1.TracingProvider.SamplingConfig.Endpoint
-	23, // 38: encore.runtime.v1.TracingProvider.SamplingConfig.pubsub_subscription:type_name -> encore.runtime.v1.TracingProvider.SamplingConfig.PubSubSubscription
-	28, // 39: encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.monitored_resource_labels:type_name -> encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.MonitoredResourceLabelsEntry
-	29, // 40: encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.metric_names:type_name -> encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.MetricNamesEntry
-	36, // 41: encore.runtime.v1.MetricsProvider.PrometheusRemoteWrite.remote_write_url:type_name -> encore.runtime.v1.SecretData
-	36, // 42: encore.runtime.v1.MetricsProvider.Datadog.api_key:type_name -> encore.runtime.v1.SecretData
-	31, // 43: encore.runtime.v1.ServiceDiscovery.ServicesEntry.value:type_name -> encore.runtime.v1.ServiceDiscovery.Location
-	7,  // 44: encore.runtime.v1.ServiceDiscovery.Location.auth_methods:type_name -> encore.runtime.v1.ServiceAuth
-	45, // [45:45] is the sub-list for method output_type
-	45, // [45:45] is the sub-list for method input_type
-	45, // [45:45] is the sub-list for extension type_name
-	45, // [45:45] is the sub-list for extension extendee
-	0,  // [0:45] is the sub-list for field type_name
+	40, // 6: encore.runtime.v1.Deployment.deployed_at:type_name -> google.protobuf.Timestamp
+	10, // 7: encore.runtime.v1.Deployment.hosted_services:type_name -> encore.runtime.v1.HostedService
+	11, // 8: encore.runtime.v1.Deployment.auth_methods:type_name -> encore.runtime.v1.ServiceAuth
+	9,  // 9: encore.runtime.v1.Deployment.observability:type_name -> encore.runtime.v1.Observability
+	16, // 10: encore.runtime.v1.Deployment.service_discovery:type_name -> encore.runtime.v1.ServiceDiscovery
+	17, // 11: encore.runtime.v1.Deployment.graceful_shutdown:type_name -> encore.runtime.v1.GracefulShutdown
+	21, // 12: encore.runtime.v1.Deployment.metrics:type_name -> encore.runtime.v1.Metric
+	8,  // 13: encore.runtime.v1.Deployment.external_http_dependencies:type_name -> encore.runtime.v1.ExternalHTTPDependency
+	41, // 14: encore.runtime.v1.ExternalHTTPDependency.idle_conn_timeout:type_name -> google.protobuf.Duration
+	41, // 15: encore.runtime.v1.ExternalHTTPDependency.connect_timeout:type_name -> google.protobuf.Duration
+	41, // 16: encore.runtime.v1.ExternalHTTPDependency.request_timeout:type_name -> google.protobuf.Duration
+	12, // 17: encore.runtime.v1.Observability.tracing:type_name -> encore.runtime.v1.TracingProvider
+	13, // 18: encore.runtime.v1.Observability.metrics:type_name -> encore.runtime.v1.MetricsProvider
+	14, // 19: encore.runtime.v1.Observability.logs:type_name -> encore.runtime.v1.LogsProvider
+	2,  // 20: encore.runtime.v1.HostedService.error_format:type_name -> encore.runtime.v1.HostedService.ErrorFormat
+	3,  // 21: encore.runtime.v1.HostedService.log_format:type_name -> encore.runtime.v1.HostedService.LogFormat
+	22, // 22: encore.runtime.v1.HostedService.health_check:type_name -> encore.runtime.v1.HostedService.HealthCheck
+	41, // 23: encore.runtime.v1.HostedService.request_timeout:type_name -> google.protobuf.Duration
+	23, // 24: encore.runtime.v1.ServiceAuth.noop:type_name -> encore.runtime.v1.ServiceAuth.NoopAuth
+	24, // 25: encore.runtime.v1.ServiceAuth.encore_auth:type_name -> encore.runtime.v1.ServiceAuth.EncoreAuth
+	25, // 26: encore.runtime.v1.TracingProvider.encore:type_name -> encore.runtime.v1.TracingProvider.EncoreTracingProvider
+	41, // 27: encore.runtime.v1.MetricsProvider.collection_interval:type_name -> google.protobuf.Duration
+	29, // 28: encore.runtime.v1.MetricsProvider.encore_cloud:type_name -> encore.runtime.v1.MetricsProvider.GCPCloudMonitoring
+	29, // 29: encore.runtime.v1.MetricsProvider.gcp:type_name -> encore.runtime.v1.MetricsProvider.GCPCloudMonitoring
+	30, // 30: encore.runtime.v1.MetricsProvider.aws:type_name -> encore.runtime.v1.MetricsProvider.AWSCloudWatch
+	31, // 31: encore.runtime.v1.MetricsProvider.prom_remote_write:type_name -> encore.runtime.v1.MetricsProvider.PrometheusRemoteWrite
+	32, // 32: encore.runtime.v1.MetricsProvider.datadog:type_name -> encore.runtime.v1.MetricsProvider.Datadog
+	42, // 33: encore.runtime.v1.EncoreAuthKey.data:type_name -> encore.runtime.v1.SecretData
+	35, // 34: encore.runtime.v1.ServiceDiscovery.services:type_name -> encore.runtime.v1.ServiceDiscovery.ServicesEntry
+	36, // 35: encore.runtime.v1.ServiceDiscovery.connections:type_name -> encore.runtime.v1.ServiceDiscovery.Connections
+	41, // 36: encore.runtime.v1.GracefulShutdown.total:type_name -> google.protobuf.Duration
+	41, // 37: encore.runtime.v1.GracefulShutdown.shutdown_hooks:type_name -> google.protobuf.Duration
+	41, // 38: encore.runtime.v1.GracefulShutdown.handlers:type_name -> google.protobuf.Duration
+	41, // 39: encore.runtime.v1.GracefulShutdown.pubsub_drain:type_name -> google.protobuf.Duration
+	15, // 40: encore.runtime.v1.EncorePlatform.platform_signing_keys:type_name -> encore.runtime.v1.EncoreAuthKey
+	20, // 41: encore.runtime.v1.EncorePlatform.encore_cloud:type_name -> encore.runtime.v1.EncoreCloudProvider
+	38, // 42: encore.runtime.v1.RateLimiter.token_bucket:type_name -> encore.runtime.v1.RateLimiter.TokenBucket
+	15, // 43: encore.runtime.v1.EncoreCloudProvider.auth_keys:type_name -> encore.runtime.v1.EncoreAuthKey
+	15, // 44: encore.runtime.v1.ServiceAuth.EncoreAuth.auth_keys:type_name -> encore.runtime.v1.EncoreAuthKey
+	26, // 45: encore.runtime.v1.TracingProvider.EncoreTracingProvider.sampling_config:type_name -> encore.runtime.v1.TracingProvider.SamplingConfig
+	43, // 46: encore.runtime.v1.TracingProvider.SamplingConfig.default:type_name -> google.protobuf.Empty
+	27, // 47: encore.runtime.v1.TracingProvider.SamplingConfig.endpoint:type_name -> encore.runtime.v1.TracingProvider.SamplingConfig.Endpoint
+	28, // 48: encore.runtime.v1.TracingProvider.SamplingConfig.pubsub_subscription:type_name -> encore.runtime.v1.TracingProvider.SamplingConfig.PubSubSubscription
+	33, // 49: encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.monitored_resource_labels:type_name -> encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.MonitoredResourceLabelsEntry
+	34, // 50: encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.metric_names:type_name -> encore.runtime.v1.MetricsProvider.GCPCloudMonitoring.MetricNamesEntry
+	42, // 51: encore.runtime.v1.MetricsProvider.PrometheusRemoteWrite.remote_write_url:type_name -> encore.runtime.v1.SecretData
+	42, // 52: encore.runtime.v1.MetricsProvider.Datadog.api_key:type_name -> encore.runtime.v1.SecretData
+	37, // 53: encore.runtime.v1.ServiceDiscovery.ServicesEntry.value:type_name -> encore.runtime.v1.ServiceDiscovery.Location
+	41, // 54: encore.runtime.v1.ServiceDiscovery.Connections.idle_conn_timeout:type_name -> google.protobuf.Duration
+	11, // 55: encore.runtime.v1.ServiceDiscovery.Location.auth_methods:type_name -> encore.runtime.v1.ServiceAuth
+	4,  // 56: encore.runtime.v1.ServiceDiscovery.Location.load_balancing:type_name -> encore.runtime.v1.ServiceDiscovery.LoadBalancing
+	57, // [57:57] is the sub-list for method output_type
+	57, // [57:57] is the sub-list for method input_type
+	57, // [57:57] is the sub-list for extension type_name
+	57, // [57:57] is the sub-list for extension extendee
+	0,  // [0:57] is the sub-list for field type_name
 }
 
 func init() { file_encore_runtime_v1_runtime_proto_init() }
@@ -2307,15 +2856,17 @@ func file_encore_runtime_v1_runtime_proto_init() {
 	file_encore_runtime_v1_infra_proto_init()
 	file_encore_runtime_v1_secretdata_proto_init()
 	file_encore_runtime_v1_runtime_proto_msgTypes[0].OneofWrappers = []any{}
-	file_encore_runtime_v1_runtime_proto_msgTypes[4].OneofWrappers = []any{}
-	file_encore_runtime_v1_runtime_proto_msgTypes[5].OneofWrappers = []any{
+	file_encore_runtime_v1_runtime_proto_msgTypes[2].OneofWrappers = []any{}
+	file_encore_runtime_v1_runtime_proto_msgTypes[3].OneofWrappers = []any{}
+	file_encore_runtime_v1_runtime_proto_msgTypes[5].OneofWrappers = []any{}
+	file_encore_runtime_v1_runtime_proto_msgTypes[6].OneofWrappers = []any{
 		(*ServiceAuth_Noop)(nil),
 		(*ServiceAuth_EncoreAuth_)(nil),
 	}
-	file_encore_runtime_v1_runtime_proto_msgTypes[6].OneofWrappers = []any{
+	file_encore_runtime_v1_runtime_proto_msgTypes[7].OneofWrappers = []any{
 		(*TracingProvider_Encore)(nil),
 	}
-	file_encore_runtime_v1_runtime_proto_msgTypes[7].OneofWrappers = []any{
+	file_encore_runtime_v1_runtime_proto_msgTypes[8].OneofWrappers = []any{
 		(*MetricsProvider_EncoreCloud)(nil),
 		(*MetricsProvider_Gcp)(nil),
 		(*MetricsProvider_Aws)(nil),
@@ -2323,24 +2874,26 @@ func file_encore_runtime_v1_runtime_proto_init() {
 		(*MetricsProvider_Datadog_)(nil),
 	}
 	file_encore_runtime_v1_runtime_proto_msgTypes[12].OneofWrappers = []any{}
-	file_encore_runtime_v1_runtime_proto_msgTypes[13].OneofWrappers = []any{
+	file_encore_runtime_v1_runtime_proto_msgTypes[13].OneofWrappers = []any{}
+	file_encore_runtime_v1_runtime_proto_msgTypes[14].OneofWrappers = []any{
 		(*RateLimiter_TokenBucket_)(nil),
 	}
-	file_encore_runtime_v1_runtime_proto_msgTypes[18].OneofWrappers = []any{}
-	file_encore_runtime_v1_runtime_proto_msgTypes[19].OneofWrappers = []any{
+	file_encore_runtime_v1_runtime_proto_msgTypes[20].OneofWrappers = []any{}
+	file_encore_runtime_v1_runtime_proto_msgTypes[21].OneofWrappers = []any{
 		(*TracingProvider_SamplingConfig_Default)(nil),
 		(*TracingProvider_SamplingConfig_Service)(nil),
 		(*TracingProvider_SamplingConfig_Endpoint_)(nil),
 		(*TracingProvider_SamplingConfig_Topic)(nil),
 		(*TracingProvider_SamplingConfig_PubsubSubscription)(nil),
 	}
+	file_encore_runtime_v1_runtime_proto_msgTypes[32].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_encore_runtime_v1_runtime_proto_rawDesc), len(file_encore_runtime_v1_runtime_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   31,
+			NumEnums:      5,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -84,6 +84,7 @@ type SecretData struct {
 	//	*SecretData_Embedded
 	//	*SecretData_Env
 	//	*SecretData_Provider
+	//	*SecretData_File
 	Source isSecretData_Source `protobuf_oneof:"source"`
 	// How the value is encoded.
 	Encoding SecretData_Encoding `protobuf:"varint,20,opt,name=encoding,proto3,enum=encore.runtime.v1.SecretData_Encoding" json:"encoding,omitempty"`
@@ -161,6 +162,15 @@ func (x *SecretData) GetProvider() *SecretData_ProviderRef {
 	return nil
 }
 
+func (x *SecretData) GetFile() string {
+	if x != nil {
+		if x, ok := x.Source.(*SecretData_File); ok {
+			return x.File
+		}
+	}
+	return ""
+}
+
 func (x *SecretData) GetEncoding() SecretData_Encoding {
 	if x != nil {
 		return x.Encoding
@@ -207,12 +217,19 @@ type SecretData_Provider struct {
 	Provider *SecretData_ProviderRef `protobuf:"bytes,3,opt,name=provider,proto3,oneof"`
 }
 
+type SecretData_File struct {
+	// Read the secret data from the file at the given path.
+	File string `protobuf:"bytes,4,opt,name=file,proto3,oneof"`
+}
+
 func (*SecretData_Embedded) isSecretData_Source() {}
 
 func (*SecretData_Env) isSecretData_Source() {}
 
 func (*SecretData_Provider) isSecretData_Source() {}
 
+func (*SecretData_File) isSecretData_Source() {}
+
 type isSecretData_SubPath interface {
 	isSecretData_SubPath()
 }
@@ -303,12 +320,13 @@ var File_encore_runtime_v1_secretdata_proto protoreflect.FileDescriptor
 
 const file_encore_runtime_v1_secretdata_proto_rawDesc = "" +
 	"\n" +
-	"\"encore/runtime/v1/secretdata.proto\x12\x11encore.runtime.v1\"\xad\x03\n" +
+	"\"encore/runtime/v1/secretdata.proto\x12\x11encore.runtime.v1\"\xc3\x03\n" +
 	"\n" +
 	"SecretData\x12\x1c\n" +
 	"\bembedded\x18\x01 \x01(\fH\x00R\bembedded\x12\x12\n" +
 	"\x03env\x18\x02 \x01(\tH\x00R\x03env\x12G\n" +
-	"\bprovider\x18\x03 \x01(\v2).encore.runtime.v1.SecretData.ProviderRefH\x00R\bprovider\x12B\n" +
+	"\bprovider\x18\x03 \x01(\v2).encore.runtime.v1.SecretData.ProviderRefH\x00R\bprovider\x12\x14\n" +
+	"\x04file\x18\x04 \x01(\tH\x00R\x04file\x12B\n" +
 	"\bencoding\x18\x14 \x01(\x0e2&.encore.runtime.v1.SecretData.EncodingR\bencoding\x12\x1b\n" +
 	"\bjson_key\x18\n" +
 	" \x01(\tH\x01R\ajsonKey\x1aZ\n" +
@@ -322,7 +340,7 @@ const file_encore_runtime_v1_secretdata_proto_rawDesc = "" +
 	"\rENCODING_GZIP\x10\x02B\b\n" +
 	"\x06sourceB\n" +
 	"\n" +
-	"\bsub_pathJ\x04\b\x04\x10\n" +
+	"\bsub_pathJ\x04\b\x05\x10\n" +
 	"J\x04\b\f\x10\x14B,Z*encr.dev/proto/encore/runtime/v1;runtimev1b\x06proto3"
 
 var (
@@ -363,6 +381,7 @@ func file_encore_runtime_v1_secretdata_proto_init() {
 		(*SecretData_Embedded)(nil),
 		(*SecretData_Env)(nil),
 		(*SecretData_Provider)(nil),
+		(*SecretData_File)(nil),
 		(*SecretData_JsonKey)(nil),
 	}
 	type x struct{}
@@ -377,6 +377,7 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
                     seconds: t as i64,
                     nanos: 0,
                 }),
+                pubsub_drain: None,
             });
 
     // Map Auth methods
@@ -439,6 +440,8 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
                     service_discovery::Location {
                         base_url: sd.base_url,
                         auth_methods: svc_auth_methods,
+                        load_balancing: None,
+                        replica_urls: vec![],
                     },
                 )
             })
@@ -446,6 +449,7 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
 
         pbruntime::ServiceDiscovery {
             services: services_mapped,
+            connections: None,
         }
     });
 
@@ -456,6 +460,7 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
             .map(|os| match os {
                 ObjectStorage::GCS(gcs) => pbruntime::BucketCluster {
                     rid: get_next_rid(),
+                    default_signed_url_ttl: None,
                     provider: Some(pbruntime::bucket_cluster::Provider::Gcs(
                         pbruntime::bucket_cluster::Gcs {
                             endpoint: gcs.endpoint,
@@ -471,12 +476,15 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
                             cloud_name: bucket.name,
                             key_prefix: bucket.key_prefix,
                             public_base_url: bucket.public_base_url,
+                            kms_key: None,
+                            access_mode: pbruntime::bucket::AccessMode::Unspecified as i32,
                             rid: get_next_rid(),
                         })
                         .collect(),
                 },
                 ObjectStorage::S3(s3) => pbruntime::BucketCluster {
                     rid: get_next_rid(),
+                    default_signed_url_ttl: None,
                     provider: Some(pbruntime::bucket_cluster::Provider::S3(
                         pbruntime::bucket_cluster::S3 {
                             region: s3.region,
@@ -496,6 +504,8 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
                             cloud_name: bucket.name,
                             key_prefix: bucket.key_prefix,
                             public_base_url: bucket.public_base_url,
+                            kms_key: None,
+                            access_mode: pbruntime::bucket::AccessMode::Unspecified as i32,
                             rid: get_next_rid(),
                         })
                         .collect(),
@@ -583,6 +593,12 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
                     base_url: metadata.base_url.clone().unwrap_or_default(),
                     hostnames: vec![],
                     cors: cors.clone(),
+                    request_timeout: None,
+                    route_timeouts: vec![],
+                    base_urls: vec![],
+                    rate_limit: None,
+                    max_request_body_size: None,
+                    variant: None,
                 })
                 .collect::<Vec<_>>()
         })
@@ -603,6 +619,10 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
                         name: service.clone(),
                         worker_threads: infra.worker_threads,
                         log_config: infra.log_config.clone(),
+                        error_format: None,
+                        log_format: None,
+                        health_check: None,
+                        request_timeout: None,
                     })
                     .collect()
             })
@@ -620,6 +640,10 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
                 services: m.services,
             })
             .collect(),
+        external_http_dependencies: Vec::new(),
+        vcs_revision: None,
+        vcs_uncommitted: false,
+        traffic_weight: None,
     });
 
     let mut credentials = Credentials {
@@ -671,17 +695,23 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
                             client_cert_rid: client_cert,
                             username: db.username,
                             password: Some(map_env_string_to_secret_data(&db.password)),
+                            rotation: None,
                         };
                         credentials.sql_roles.push(role);
                         SqlDatabase {
                             rid: get_next_rid(),
                             encore_name: name.clone(),
                             cloud_name: db.name.unwrap_or(name),
+                            search_path: vec![],
                             conn_pools: vec![SqlConnectionPool {
                                 is_readonly: false,
                                 role_rid,
                                 min_connections: db.min_connections.unwrap_or(0),
                                 max_connections: db.max_connections.unwrap_or(100),
+                                idle_reap_interval: None,
+                                warmup_on_start: false,
+                                max_lifetime: None,
+                                max_idle_time: None,
                             }],
                         }
                     })
@@ -693,6 +723,7 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
                         rid: get_next_rid(),
                         host: server.host,
                         kind: pbruntime::ServerKind::Primary as i32,
+                        driver: pbruntime::sql_server::Driver::Postgres as i32,
                         tls_config: server.tls_config.map_or_else(
                             || Some(TlsConfig::default()),
                             |tls| match tls.disabled {
@@ -702,11 +733,13 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
                                     disable_tls_hostname_verification: tls
                                         .disable_tls_hostname_verification,
                                     disable_ca_validation: tls.disable_ca_validation,
+                                    server_name: None,
                                 }),
                             },
                         ),
                     }],
                     databases,
+                    ssh_tunnel: None,
                 }
             })
             .collect()
@@ -763,6 +796,10 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
                         role_rid,
                         min_connections: redis.min_connections.unwrap_or(0),
                         max_connections: redis.max_connections.unwrap_or(100),
+                        idle_reap_interval: None,
+                        warmup_on_start: false,
+                        max_lifetime: None,
+                        max_idle_time: None,
                     }],
                 };
 
@@ -781,6 +818,7 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
                                     disable_tls_hostname_verification: tls
                                         .disable_tls_hostname_verification,
                                     disable_ca_validation: tls.disable_ca_validation,
+                                    server_name: None,
                                 }),
                             },
                         ),
@@ -844,6 +882,7 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
                                         topic_cloud_name: topic.name.clone(),
                                         subscription_cloud_name: sub.name.clone(),
                                         push_only: sub.push_config.is_some(),
+                                        dead_letter: None,
                                         provider_config: Some(
                                             pub_sub_subscription::ProviderConfig::GcpConfig(
                                                 pub_sub_subscription::GcpConfig {
@@ -860,6 +899,9 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
                                                         .push_config
                                                         .as_ref()
                                                         .map(|pc| pc.jwt_audience.clone()),
+                                                    push_endpoint: None,
+                                                    ack_deadline: None,
+                                                    message_retention: None,
                                                 },
                                             ),
                                         ),
@@ -899,6 +941,7 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
                                         topic_cloud_name: topic.arn.clone(),
                                         subscription_cloud_name: sub.url.clone(),
                                         push_only: false, // AWS SQS doesn't typically use push config
+                                        dead_letter: None,
                                         provider_config: None, // AWS doesn't need additional provider config
                                     }
                                 })
@@ -937,6 +980,7 @@ pub fn map_infra_to_runtime(infra: InfraConfig) -> RuntimeConfig {
                                         topic_cloud_name: topic.name.clone(), // Using topic name for simplicity
                                         subscription_cloud_name: sub.name.clone(),
                                         push_only: false, // NSQ is pull-based, no push config
+                                        dead_letter: None,
                                         provider_config: None, // No additional provider config for NSQ
                                     }
                                 })
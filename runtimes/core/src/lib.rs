@@ -701,24 +701,8 @@ fn runtime_config_from_env() -> Result<runtimepb::RuntimeConfig, ParseError> {
         Err(e) => return Err(ParseError::EnvVar(e)),
     };
 
-    if cfg.starts_with("gzip:") {
-        // Parse the remainder as base64-encoded gzip data.
-        let cfg = cfg.as_bytes();
-        let cfg = &cfg["gzip:".len()..];
-        let gzip_data = base64::engine::general_purpose::STANDARD
-            .decode(cfg)
-            .map_err(ParseError::Base64)?;
-
-        let mut decoder = flate2::read::GzDecoder::new(&gzip_data[..]);
-        let mut raw_data = Vec::new();
-        decoder.read_to_end(&mut raw_data).map_err(ParseError::IO)?;
-        runtimepb::RuntimeConfig::decode(&raw_data[..]).map_err(ParseError::Proto)
-    } else {
-        let decoded = base64::engine::general_purpose::STANDARD
-            .decode(cfg.as_bytes())
-            .map_err(ParseError::Base64)?;
-        runtimepb::RuntimeConfig::decode(&decoded[..]).map_err(ParseError::Proto)
-    }
+    let raw_data = decode_env_data(&cfg)?;
+    runtimepb::RuntimeConfig::decode(&raw_data[..]).map_err(ParseError::Proto)
 }
 
 /// Load the runtime config from a location, which is either a local file path
@@ -879,23 +863,33 @@ fn meta_from_env() -> Result<metapb::Data, ParseError> {
         Err(e) => return Err(ParseError::EnvVar(e)),
     };
 
-    if cfg.starts_with("gzip:") {
+    let raw_data = decode_env_data(&cfg)?;
+    metapb::Data::decode(&raw_data[..]).map_err(ParseError::Proto)
+}
+
+/// Decodes base64-encoded data from an environment variable.
+/// A "gzip:" or "zstd:" prefix indicates the data is compressed.
+fn decode_env_data(cfg: &str) -> Result<Vec<u8>, ParseError> {
+    if let Some(rest) = cfg.strip_prefix("gzip:") {
         // Parse the remainder as base64-encoded gzip data.
-        let cfg = cfg.as_bytes();
-        let cfg = &cfg["gzip:".len()..];
         let gzip_data = base64::engine::general_purpose::STANDARD
-            .decode(cfg)
+            .decode(rest.as_bytes())
             .map_err(ParseError::Base64)?;
 
         let mut decoder = flate2::read::GzDecoder::new(&gzip_data[..]);
         let mut raw_data = Vec::new();
         decoder.read_to_end(&mut raw_data).map_err(ParseError::IO)?;
-        metapb::Data::decode(&raw_data[..]).map_err(ParseError::Proto)
+        Ok(raw_data)
+    } else if let Some(rest) = cfg.strip_prefix("zstd:") {
+        // Parse the remainder as base64-encoded zstd data.
+        let zstd_data = base64::engine::general_purpose::STANDARD
+            .decode(rest.as_bytes())
+            .map_err(ParseError::Base64)?;
+        zstd::stream::decode_all(&zstd_data[..]).map_err(ParseError::IO)
     } else {
-        let decoded = base64::engine::general_purpose::STANDARD
+        base64::engine::general_purpose::STANDARD
             .decode(cfg.as_bytes())
-            .map_err(ParseError::Base64)?;
-        metapb::Data::decode(&decoded[..]).map_err(ParseError::Proto)
+            .map_err(ParseError::Base64)
     }
 }
 
@@ -34,6 +34,10 @@ impl ProcessConfig {
                         name: s.clone(),
                         log_config: None,
                         worker_threads: None,
+                        error_format: None,
+                        log_format: None,
+                        health_check: None,
+                        request_timeout: None,
                     })
             })
             .collect();
@@ -66,6 +70,8 @@ impl ProcessConfig {
                 runtimepb::service_discovery::Location {
                     base_url: base_url.clone(),
                     auth_methods: deployment.auth_methods.clone(),
+                    load_balancing: None,
+                    replica_urls: vec![],
                 },
             );
         }
@@ -704,6 +704,9 @@ fn new_cluster(cluster: &pb::PubSubCluster) -> Arc<dyn Cluster> {
         pb::pub_sub_cluster::Provider::Azure(_) => {
             log::error!("Azure Pub/Sub not yet supported: {}", cluster.rid);
         }
+        pb::pub_sub_cluster::Provider::Kafka(_) => {
+            log::error!("Kafka Pub/Sub not yet supported: {}", cluster.rid);
+        }
     }
 
     Arc::new(NoopCluster)
@@ -139,6 +139,7 @@ pub enum ResolveError {
     /// A secret value uses the `envref:` indirection but one of the referenced
     /// chunk env vars is not set.
     EnvRefChunkNotFound,
+    FileNotReadable,
     JsonKeyNotFound,
     JsonValueNotString,
     InvalidBase64,
@@ -162,6 +163,7 @@ impl Display for ResolveError {
             ResolveError::EnvRefChunkNotFound => {
                 write!(f, "referenced envref chunk environment variable not found")
             }
+            ResolveError::FileNotReadable => write!(f, "secret file not readable"),
             ResolveError::JsonKeyNotFound => write!(f, "JSON key not found"),
             ResolveError::JsonValueNotString => write!(f, "JSON value is not a string"),
             ResolveError::InvalidBase64 => write!(f, "invalid base64"),
@@ -183,6 +185,9 @@ fn resolve(data: &SecretData) -> ResolveResult<Vec<u8>> {
     let value = match &data.source {
         Some(Source::Embedded(data)) => data.clone(),
         Some(Source::Env(name)) => resolve_env_source(name)?,
+        Some(Source::File(path)) => {
+            std::fs::read(path).map_err(|_| ResolveError::FileNotReadable)?
+        }
         Some(Source::Provider(_)) => return Err(ResolveError::ProviderNotResolved),
         None => Err(ResolveError::InvalidSecretSource)?,
     };
@@ -257,11 +257,13 @@ fn databases_from_cfg(
         };
 
         for db in c.databases {
-            // Get the read-write pool for this db.
-            let pool = db.conn_pools.into_iter().find(|p| !p.is_readonly);
-            let Some(pool) = pool else {
+            // Get the read-write pool for this db, falling back to a
+            // read-only pool for deployments that only read from it.
+            let mut pools = db.conn_pools;
+            let idx = pools.iter().position(|p| !p.is_readonly).unwrap_or(0);
+            let Some(pool) = (idx < pools.len()).then(|| pools.swap_remove(idx)) else {
                 log::warn!(
-                    "no read-write pool found for database {}, skipping",
+                    "no connection pool found for database {}, skipping",
                     db.encore_name
                 );
                 continue;
@@ -258,6 +258,18 @@ type PubsubProvider struct {
 	AWS         *AWSPubsubProvider         `json:"aws,omitempty"`          // set if the provider is AWS
 	Azure       *AzureServiceBusProvider   `json:"azure,omitempty"`        // set if the provider is Azure
 	EncoreCloud *EncoreCloudPubsubProvider `json:"encore_cloud,omitempty"` // set if the provider is Encore Cloud
+	Kafka       *KafkaPubsubProvider       `json:"kafka,omitempty"`        // set if the provider is Kafka
+}
+
+type KafkaPubsubProvider struct {
+	Brokers []string   `json:"brokers"`
+	SASL    *KafkaSASL `json:"sasl,omitempty"` // nil if SASL is not used
+}
+
+type KafkaSASL struct {
+	Mechanism string `json:"mechanism"` // e.g. "PLAIN" or "SCRAM-SHA-512"
+	Username  string `json:"username"`
+	Password  string `json:"password"`
 }
 
 type AzureServiceBusProvider struct {
@@ -265,6 +277,12 @@ type AzureServiceBusProvider struct {
 }
 type NSQProvider struct {
 	Host string `json:"host"`
+
+	// NameTemplate is a template for the names of topics and channels in NSQ,
+	// so that environments sharing an NSQ instance don't collide.
+	// "{env}" is replaced by the environment name and "{name}" by the
+	// Encore name of the topic or subscription. If empty the Encore name is used.
+	NameTemplate string `json:"name_template,omitempty"`
 }
 
 type EncoreCloudPubsubProvider struct{}
@@ -301,6 +319,19 @@ type PubsubSubscription struct {
 	// GCP contains GCP-specific configuration.
 	// It is set if the subscription exists in GCP.
 	GCP *PubsubSubscriptionGCPData `json:"gcp,omitempty"`
+
+	// DeadLetter is the dead-letter policy for the subscription, if any.
+	DeadLetter *PubsubDeadLetterPolicy `json:"dead_letter,omitempty"`
+}
+
+type PubsubDeadLetterPolicy struct {
+	// TopicName is the Encore name of the dead-letter topic.
+	TopicName string `json:"topic_name"`
+
+	// MaxDeliveryAttempts is the number of delivery attempts before
+	// a message is forwarded to the dead-letter topic.
+	// If zero the provider default is used.
+	MaxDeliveryAttempts int `json:"max_delivery_attempts,omitempty"`
 }
 
 type PubsubTopicGCPData struct {
@@ -316,6 +347,21 @@ type PubsubSubscriptionGCPData struct {
 	// messages being delivered over push.
 	// If empty pushes are not accepted.
 	PushServiceAccount string `json:"push_service_account"`
+
+	// PushJWTAudience is the audience to use when validating
+	// JWTs delivered over push. If empty any audience is allowed.
+	PushJWTAudience string `json:"push_jwt_audience,omitempty"`
+
+	// PushEndpoint is the endpoint messages are pushed to.
+	PushEndpoint string `json:"push_endpoint,omitempty"`
+
+	// AckDeadline is how long to wait for a message to be acknowledged
+	// before redelivering it. If zero it defaults to 10 seconds.
+	AckDeadline time.Duration `json:"ack_deadline,omitempty"`
+
+	// MessageRetention is how long to retain unacknowledged messages.
+	// If zero it defaults to 7 days.
+	MessageRetention time.Duration `json:"message_retention,omitempty"`
 }
 
 type StaticPubsubTopic struct {
@@ -341,6 +387,13 @@ type SQLServer struct {
 	ClientCert string `json:"client_cert,omitempty"`
 	// ClientKey is the PEM-encoded client key, or "" if not required.
 	ClientKey string `json:"client_key,omitempty"`
+	// ServerName is the name to verify the server's certificate against,
+	// or "" to use the host.
+	ServerName string `json:"server_name,omitempty"`
+
+	// Driver is the database driver to use, "postgres" or "mysql".
+	// If empty it defaults to "postgres".
+	Driver string `json:"driver,omitempty"`
 }
 
 type SQLDatabase struct {
@@ -357,6 +410,26 @@ type SQLDatabase struct {
 	// MaxConnections is the maximum number of open connections to use
 	// for this database. If zero it defaults to 30.
 	MaxConnections int `json:"max_connections"`
+
+	// SearchPath is the schema search path to set on new connections.
+	// If empty the server's default search path is used.
+	SearchPath []string `json:"search_path,omitempty"`
+
+	// ReadOnly specifies whether connections should be read-only,
+	// for services that only read from the database.
+	ReadOnly bool `json:"read_only,omitempty"`
+
+	// WarmupOnStart specifies whether to open MinConnections connections
+	// at startup instead of lazily on first use.
+	WarmupOnStart bool `json:"warmup_on_start,omitempty"`
+
+	// MaxLifetime is the maximum time a connection may be reused.
+	// If zero connections are reused indefinitely.
+	MaxLifetime time.Duration `json:"max_lifetime,omitempty"`
+
+	// MaxIdleTime is the maximum time a connection may be idle before it's closed.
+	// If zero idle connections are kept indefinitely.
+	MaxIdleTime time.Duration `json:"max_idle_time,omitempty"`
 }
 
 type RedisServer struct {
@@ -381,6 +454,9 @@ type RedisServer struct {
 	ClientCert string `json:"client_cert,omitempty"`
 	// ClientKey is the PEM-encoded client key, or "" if not required.
 	ClientKey string `json:"client_key,omitempty"`
+	// ServerName is the name to verify the server's certificate against,
+	// or "" to use the host.
+	ServerName string `json:"server_name,omitempty"`
 
 	// InMemory tells the runtime to use an in-memory store
 	// instead of connecting to this server.
@@ -407,11 +483,27 @@ type RedisDatabase struct {
 	// to use the same physical Redis database for local development
 	// without having to coordinate and persist database index ids.
 	KeyPrefix string `json:"key_prefix"`
+
+	// WarmupOnStart specifies whether to open MinConnections connections
+	// at startup instead of lazily on first use.
+	WarmupOnStart bool `json:"warmup_on_start,omitempty"`
+
+	// MaxLifetime is the maximum time a connection may be reused.
+	// If zero connections are reused indefinitely.
+	MaxLifetime time.Duration `json:"max_lifetime,omitempty"`
+
+	// MaxIdleTime is the maximum time a connection may be idle before it's closed.
+	// If zero idle connections are kept indefinitely.
+	MaxIdleTime time.Duration `json:"max_idle_time,omitempty"`
 }
 
 type BucketProvider struct {
 	S3  *S3BucketProvider  `json:"s3,omitempty"`  // set if the provider is S3
 	GCS *GCSBucketProvider `json:"gcs,omitempty"` // set if the provider is GCS
+
+	// DefaultSignedURLTTL is the expiry of signed URLs when no TTL
+	// is given by the caller. If nil, it defaults to one hour.
+	DefaultSignedURLTTL *time.Duration `json:"default_signed_url_ttl,omitempty"`
 }
 
 type S3BucketProvider struct {
@@ -162,8 +162,20 @@ func parseProviders(s string) map[string]boundRef {
 }
 
 // parse parses secrets in "key1=base64(val1),key2=base64(val2)" format into a map.
+// Secrets prefixed with "json:" are instead a base64-encoded JSON object.
 func parse(s string) map[string]string {
 	s = expandEnvRef(s)
+	if rest, isJSON := strings.CutPrefix(s, "json:"); isJSON {
+		b, err := base64.StdEncoding.DecodeString(rest)
+		if err != nil {
+			log.Fatalln("encore runtime: fatal error: could not decode app secrets:", err)
+		}
+		m := make(map[string]string)
+		if err := json.Unmarshal(b, &m); err != nil {
+			log.Fatalln("encore runtime: fatal error: invalid app secrets:", err)
+		}
+		return m
+	}
 	s, isGzipped := strings.CutPrefix(s, "gzip:")
 	if isGzipped {
 		var b []byte
@@ -116,6 +116,16 @@ func TestParse_EnvRef(t *testing.T) {
 	c.Assert(m["KEY"], qt.Equals, "world")
 }
 
+func TestParse_JSON(t *testing.T) {
+	c := qt.New(t)
+
+	data, err := json.Marshal(map[string]string{"KEY": "world", "OTHER": "a=b,c"})
+	c.Assert(err, qt.IsNil)
+
+	m := parse("json:" + base64.StdEncoding.EncodeToString(data))
+	c.Assert(m, qt.DeepEquals, map[string]string{"KEY": "world", "OTHER": "a=b,c"})
+}
+
 func TestParseProviders_Empty(t *testing.T) {
 	c := qt.New(t)
 	refs := parseProviders("")
@@ -120,7 +120,7 @@ func (mgr *Manager) newClient(rdb *config.RedisDatabase) (*redis.Client, error)
 	}
 
 	if srv.EnableTLS || srv.ServerCACert != "" || srv.ClientCert != "" {
-		opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12, ServerName: srv.ServerName}
 		if srv.ServerCACert != "" {
 			caCertPool := x509.NewCertPool()
 			if !caCertPool.AppendCertsFromPEM([]byte(srv.ServerCACert)) {
@@ -30,6 +30,10 @@ type Bucket struct {
 
 	// publicBaseURL, if the bucket is public
 	publicBaseURL *url.URL
+
+	// signedURLTTL is the expiry to use for signed URLs
+	// when no TTL option is provided.
+	signedURLTTL time.Duration
 }
 
 // BucketConfig is the configuration for a Bucket.
@@ -50,10 +54,11 @@ func newBucket(mgr *Manager, name string) *Bucket {
 	if !ok {
 		// No runtime config; return the noop implementation.
 		return &Bucket{
-			mgr:        mgr,
-			runtimeCfg: &config.Bucket{EncoreName: name},
-			impl:       &noop.BucketImpl{},
-			name:       name,
+			mgr:          mgr,
+			runtimeCfg:   &config.Bucket{EncoreName: name},
+			impl:         &noop.BucketImpl{},
+			name:         name,
+			signedURLTTL: time.Hour,
 		}
 	}
 
@@ -74,6 +79,11 @@ func newBucket(mgr *Manager, name string) *Bucket {
 				}
 			}
 
+			signedURLTTL := time.Hour
+			if provider.DefaultSignedURLTTL != nil {
+				signedURLTTL = *provider.DefaultSignedURLTTL
+			}
+
 			return &Bucket{
 				mgr:             mgr,
 				runtimeCfg:      bkt,
@@ -81,6 +91,7 @@ func newBucket(mgr *Manager, name string) *Bucket {
 				name:            name,
 				baseCloudPrefix: bkt.KeyPrefix,
 				publicBaseURL:   publicBaseURL,
+				signedURLTTL:    signedURLTTL,
 			}
 		}
 
@@ -597,7 +608,7 @@ func (b *Bucket) SignedUploadURL(ctx context.Context, object string, options ...
 		o.applyUploadURL(&opt)
 	}
 	if opt.TTL == 0 {
-		opt.TTL = time.Hour
+		opt.TTL = b.signedURLTTL
 	}
 	if opt.TTL > 7*24*time.Hour {
 		return nil, types.ErrInvalidArgument
@@ -623,7 +634,7 @@ func (b *Bucket) SignedDownloadURL(ctx context.Context, object string, options .
 		o.applyDownloadURL(&opt)
 	}
 	if opt.TTL == 0 {
-		opt.TTL = time.Hour
+		opt.TTL = b.signedURLTTL
 	}
 	if opt.TTL > 7*24*time.Hour {
 		return nil, types.ErrInvalidArgument
@@ -172,6 +172,17 @@ func dbConf(srv *config.SQLServer, db *config.SQLDatabase, dbNameOverride string
 		return nil, fmt.Errorf("invalid database uri: %v", err)
 	}
 
+	if len(db.SearchPath) > 0 {
+		schemas := make([]string, len(db.SearchPath))
+		for i, schema := range db.SearchPath {
+			schemas[i] = pgx.Identifier{schema}.Sanitize()
+		}
+		cfg.ConnConfig.RuntimeParams["search_path"] = strings.Join(schemas, ", ")
+	}
+	if db.ReadOnly {
+		cfg.ConnConfig.RuntimeParams["default_transaction_read_only"] = "on"
+	}
+
 	// Set the pool size based on the config.
 	cfg.MaxConns = 30
 	if n := db.MaxConnections; n > 0 {
@@ -188,6 +199,10 @@ func dbConf(srv *config.SQLServer, db *config.SQLDatabase, dbNameOverride string
 		cfg.ConnConfig.TLSConfig.ClientCAs = caCertPool
 	}
 
+	if srv.ServerName != "" && cfg.ConnConfig.TLSConfig != nil {
+		cfg.ConnConfig.TLSConfig.ServerName = srv.ServerName
+	}
+
 	// If we have a client cert, set it in the TLS config.
 	if srv.ClientCert != "" {
 		cert, err := tls.X509KeyPair([]byte(srv.ClientCert), []byte(srv.ClientKey))
@@ -123,6 +123,32 @@ module example.com
 		c.Check(string(pkg.Files[0].Contents()), qt.Equals, string(a.Files[0].Data))
 	})
 
+	t.Run("with_external_test_package_ignored", func(t *testing.T) {
+		c := qt.New(t)
+		a := parse(`
+-- foo/foo.go --
+package foo // main file
+-- foo/helpers.go --
+package foo_test // external test file without the _test.go suffix
+
+import "example.com/foo"
+
+func Broken() { foo.Missing() }
+-- go.mod --
+module example.com
+	`)
+
+		tc := testutil.NewContext(c, false, a)
+		tc.FailTestOnErrors()
+
+		l := pkginfo.New(tc.Context)
+
+		pkg, ok := l.LoadPkg(token.NoPos, "example.com/foo")
+		c.Assert(ok, qt.Equals, true)
+		c.Assert(pkg.Files, qt.HasLen, 1)
+		c.Check(pkg.Files[0].Name, qt.Equals, "foo.go")
+	})
+
 	t.Run("with_parse_failure", func(t *testing.T) {
 		c := qt.New(t)
 
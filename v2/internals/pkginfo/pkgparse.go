@@ -170,6 +170,13 @@ func (l *Loader) parseAST(s loadPkgSpec) ([]*ast.Package, []*File) {
 		}
 
 		pkgName := astFile.Name.Name
+		isTestFile := strings.HasSuffix(d.baseName, "_test.go") || strings.HasSuffix(pkgName, "_test")
+		if isTestFile && !l.c.ParseTests {
+			// Files belonging to an external test package are test files
+			// even without the _test.go suffix; skip them too.
+			continue
+		}
+
 		pkg, found := seenPkgs[pkgName]
 		if !found {
 			pkg = &ast.Package{
@@ -182,7 +189,6 @@ func (l *Loader) parseAST(s loadPkgSpec) ([]*ast.Package, []*File) {
 
 		pkg.Files[d.ioPath] = astFile
 
-		isTestFile := strings.HasSuffix(d.baseName, "_test.go") || strings.HasSuffix(pkgName, "_test")
 		files = append(files, &File{
 			l:        l,
 			Name:     d.baseName,